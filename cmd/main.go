@@ -22,7 +22,7 @@ func main() {
 	}
 
 	// Initialize Kubernetes client
-	k8sClient, err := kubernetes.NewClient(cfg)
+	k8sClient, err := kubernetes.NewClientFromKubeconfig(cfg)
 	if err != nil {
 		log.Fatalf("Failed to create Kubernetes client: %v", err)
 	}
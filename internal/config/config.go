@@ -3,6 +3,8 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -11,21 +13,83 @@ import (
 // Config represents the application configuration
 type Config struct {
 	// Kubernetes configuration
-	KubeConfig string `yaml:"kubeconfig"`
-	Context    string `yaml:"context"`
-	Namespace  string `yaml:"namespace"`
+	KubeConfig    string `yaml:"kubeconfig"`     // Path, or list of paths separated like $KUBECONFIG
+	KubeConfigDir string `yaml:"kubeconfig_dir"` // Directory or glob of extra kubeconfig files to merge
+	Context       string `yaml:"context"`
+	Namespace     string `yaml:"namespace"`
+
+	// Namespaces to monitor when the user may not list pods in all namespaces;
+	// empty means the context's default namespace
+	FallbackNamespaces []string `yaml:"fallback_namespaces,omitempty"`
 
 	// Polling configuration
-	PollInterval time.Duration `yaml:"poll_interval"`
+	PollInterval   time.Duration `yaml:"poll_interval"`
+	RequestTimeout time.Duration `yaml:"request_timeout"` // Timeout of each API call
 
 	// UI configuration
 	ShowNotifications bool   `yaml:"show_notifications"`
 	Theme             string `yaml:"theme"`
+	MaxPodDetails     int    `yaml:"max_pod_details"` // Pods listed per pod category
 
 	// Feature flags
 	ShowMetrics bool `yaml:"show_metrics"`
 	ShowLogs    bool `yaml:"show_logs"`
 	ShowEvents  bool `yaml:"show_events"`
+
+	// How to connect: auto, kubeconfig or in-cluster
+	ConnectionMode string `yaml:"connection_mode"`
+
+	// Per-context connection settings, keyed by context name
+	Contexts map[string]ContextSettings `yaml:"contexts,omitempty"`
+
+	// Follow kubectl's current-context when Context is not set
+	FollowCurrentContext bool `yaml:"follow_current_context"`
+
+	// Additional contexts monitored alongside the current one
+	MonitoredContexts []string `yaml:"monitored_contexts"`
+
+	// Log viewer configuration
+	LogTailLines int64  `yaml:"log_tail_lines"` // Lines fetched per container
+	LogViewer    string `yaml:"log_viewer"`     // Command used to open log files; empty uses the platform default
+
+	// Endpoints alerted about health changes
+	Webhooks []Webhook `yaml:"webhooks,omitempty"`
+
+	// Commands run when health or pods change
+	Hooks              []Hook `yaml:"hooks,omitempty"`
+	MaxConcurrentHooks int    `yaml:"max_concurrent_hooks"` // Hook commands running at once
+}
+
+// Webhook is an HTTP endpoint that receives alerts
+type Webhook struct {
+	URL         string            `yaml:"url"`
+	Format      string            `yaml:"format,omitempty"`       // slack, teams, json or template
+	Template    string            `yaml:"template,omitempty"`     // Go text/template of the request body for the template format
+	MinSeverity string            `yaml:"min_severity,omitempty"` // Least severe alert sent: healthy, warning or critical
+	Headers     map[string]string `yaml:"headers,omitempty"`      // Extra request headers, e.g. Authorization
+
+	// Also send losing and regaining the connection, which is usually this machine's network
+	ConnectionAlerts bool `yaml:"connection_alerts,omitempty"`
+}
+
+// ContextSettings holds connection overrides for one context
+type ContextSettings struct {
+	Timeout           time.Duration `yaml:"timeout,omitempty"`            // Per-request timeout
+	QPS               float32       `yaml:"qps,omitempty"`                // Client-side rate limit
+	Burst             int           `yaml:"burst,omitempty"`              // Client-side burst limit
+	ImpersonateUser   string        `yaml:"impersonate_user,omitempty"`   // User to act as
+	ImpersonateGroups []string      `yaml:"impersonate_groups,omitempty"` // Groups to act as
+	ProxyURL          string        `yaml:"proxy_url,omitempty"`          // HTTP(S) or SOCKS5 proxy
+	LoginCommand      string        `yaml:"login_command,omitempty"`      // Run by "Log In" when credentials expire
+
+	// Recurring periods during which alerts about the context are muted
+	MaintenanceWindows []MaintenanceWindow `yaml:"maintenance_windows,omitempty"`
+}
+
+// MaintenanceWindow is a recurring period, e.g. of planned upgrades
+type MaintenanceWindow struct {
+	Schedule string        `yaml:"schedule"` // Cron expression of when the window starts, e.g. "0 2 * * SAT"
+	Duration time.Duration `yaml:"duration"` // How long the window lasts
 }
 
 // Constants for namespace selection
@@ -33,17 +97,77 @@ const (
 	AllNamespaces = "<all>"
 )
 
+// Connection modes
+const (
+	// ConnectionModeAuto uses the kubeconfig when it defines any context and
+	// falls back to the in-cluster service account otherwise
+	ConnectionModeAuto       = "auto"
+	ConnectionModeKubeconfig = "kubeconfig"
+	ConnectionModeInCluster  = "in-cluster"
+)
+
+// Hook runs a command when the cluster health transitions or pods change state
+type Hook struct {
+	Command string        `yaml:"command"`           // Run through the shell
+	On      []string      `yaml:"on,omitempty"`      // health, pod or both (default)
+	Pods    []string      `yaml:"pods,omitempty"`    // namespace/name globs pod hooks fire for; empty for all pods
+	Timeout time.Duration `yaml:"timeout,omitempty"` // Killed after this long
+}
+
+// Hook events
+const (
+	HookEventHealth = "health" // The cluster health transitioned
+	HookEventPod    = "pod"    // Pods moved to another state, e.g. Ready → CrashLoopBackOff
+)
+
+// Webhook payload formats
+const (
+	WebhookFormatSlack    = "slack"    // Slack incoming webhook message
+	WebhookFormatTeams    = "teams"    // Microsoft Teams Adaptive Card
+	WebhookFormatJSON     = "json"     // The alert with the cluster status and changes
+	WebhookFormatTemplate = "template" // Rendered from the webhook's template
+)
+
+// Alert severities, from least to most severe
+const (
+	SeverityHealthy  = "healthy" // Includes recoveries
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+// DefaultRequestTimeout is the default timeout of each API call made during a refresh
+const DefaultRequestTimeout = 10 * time.Second
+
+// DefaultMaxPodDetails is the number of pods listed per pod category by default
+const DefaultMaxPodDetails = 50
+
+// DefaultHookTimeout is how long hook commands may run by default
+const DefaultHookTimeout = 30 * time.Second
+
+// DefaultMaxConcurrentHooks is the number of hook commands running at once by default
+const DefaultMaxConcurrentHooks = 4
+
+// DefaultLogTailLines is the number of log lines fetched per container by default
+const DefaultLogTailLines = 500
+
 // Default configuration values
 var defaultConfig = Config{
-	KubeConfig:        getDefaultKubeConfig(),
-	Context:           "",
-	Namespace:         AllNamespaces,
-	PollInterval:      15 * time.Second,
-	ShowNotifications: true,
-	Theme:             "auto",
-	ShowMetrics:       true,
-	ShowLogs:          false,
-	ShowEvents:        true,
+	KubeConfig:           getDefaultKubeConfig(),
+	Context:              "",
+	Namespace:            AllNamespaces,
+	PollInterval:         15 * time.Second,
+	RequestTimeout:       DefaultRequestTimeout,
+	ShowNotifications:    true,
+	Theme:                "auto",
+	MaxPodDetails:        DefaultMaxPodDetails,
+	ShowMetrics:          true,
+	ShowLogs:             false,
+	ShowEvents:           true,
+	ConnectionMode:       ConnectionModeAuto,
+	FollowCurrentContext: true,
+	LogTailLines:         DefaultLogTailLines,
+	LogViewer:            "",
+	MaxConcurrentHooks:   DefaultMaxConcurrentHooks,
 }
 
 // Load loads the configuration from file or returns default configuration
@@ -98,6 +222,71 @@ func (c *Config) validate() {
 	if c.PollInterval > 5*time.Minute {
 		c.PollInterval = 5 * time.Minute
 	}
+
+	if c.RequestTimeout <= 0 {
+		c.RequestTimeout = DefaultRequestTimeout
+	}
+
+	if c.MaxPodDetails <= 0 {
+		c.MaxPodDetails = DefaultMaxPodDetails
+	}
+
+	if c.LogTailLines <= 0 {
+		c.LogTailLines = DefaultLogTailLines
+	}
+
+	switch c.ConnectionMode {
+	case ConnectionModeAuto, ConnectionModeKubeconfig, ConnectionModeInCluster:
+	default:
+		c.ConnectionMode = ConnectionModeAuto
+	}
+
+	for i := range c.Webhooks {
+		c.Webhooks[i].validate()
+	}
+
+	for i := range c.Hooks {
+		if c.Hooks[i].Timeout <= 0 {
+			c.Hooks[i].Timeout = DefaultHookTimeout
+		}
+	}
+
+	if c.MaxConcurrentHooks <= 0 {
+		c.MaxConcurrentHooks = DefaultMaxConcurrentHooks
+	}
+}
+
+// validate defaults the format to json and the minimum severity to warning
+func (w *Webhook) validate() {
+	switch w.Format {
+	case WebhookFormatSlack, WebhookFormatTeams, WebhookFormatJSON, WebhookFormatTemplate:
+	default:
+		w.Format = WebhookFormatJSON
+	}
+
+	switch w.MinSeverity {
+	case SeverityHealthy, SeverityWarning, SeverityCritical:
+	default:
+		w.MinSeverity = SeverityWarning
+	}
+}
+
+// Handles reports whether the hook runs for the event; hooks without events run for all
+func (h Hook) Handles(event string) bool {
+	if len(h.On) == 0 {
+		return true
+	}
+	for _, on := range h.On {
+		if on == event {
+			return true
+		}
+	}
+	return false
+}
+
+// ContextSettings returns the connection overrides for a context
+func (c *Config) ContextSettings(contextName string) ContextSettings {
+	return c.Contexts[contextName]
 }
 
 // getConfigPath returns the path to the configuration file
@@ -110,6 +299,71 @@ var getConfigPath = func() string {
 	return filepath.Join(homeDir, ".k8s-tray.yaml")
 }
 
+// KubeConfigPaths returns the kubeconfig files to merge, in precedence order:
+// the entries of KubeConfig followed by the files matched by KubeConfigDir
+func (c *Config) KubeConfigPaths() []string {
+	var paths []string
+	seen := make(map[string]bool)
+	add := func(path string) {
+		if path == "" || seen[path] {
+			return
+		}
+		seen[path] = true
+		paths = append(paths, path)
+	}
+
+	for _, path := range filepath.SplitList(c.KubeConfig) {
+		add(ExpandHome(path))
+	}
+
+	for _, path := range kubeConfigDirFiles(ExpandHome(c.KubeConfigDir)) {
+		add(path)
+	}
+
+	return paths
+}
+
+// kubeConfigDirFiles lists the regular files in a directory, or the files
+// matching a glob pattern, sorted by name
+func kubeConfigDirFiles(pattern string) []string {
+	if pattern == "" {
+		return nil
+	}
+
+	if info, err := os.Stat(pattern); err == nil && info.IsDir() {
+		pattern = filepath.Join(pattern, "*")
+	}
+
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil
+	}
+
+	var files []string
+	for _, match := range matches {
+		if info, err := os.Stat(match); err == nil && info.Mode().IsRegular() {
+			files = append(files, match)
+		}
+	}
+	sort.Strings(files)
+
+	return files
+}
+
+// ExpandHome replaces a leading ~ with the user's home directory
+func ExpandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+
+	return filepath.Join(homeDir, strings.TrimPrefix(path, "~"))
+}
+
 // getDefaultKubeConfig returns the default kubeconfig path
 func getDefaultKubeConfig() string {
 	if kubeconfig := os.Getenv("KUBECONFIG"); kubeconfig != "" {
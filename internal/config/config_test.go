@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -44,7 +45,10 @@ func TestLoadDefaultConfig(t *testing.T) {
 
 func TestConfigValidation(t *testing.T) {
 	cfg := &Config{
-		PollInterval: 500 * time.Millisecond, // Too short
+		PollInterval:   500 * time.Millisecond, // Too short
+		ConnectionMode: "in_cluster",           // Unknown mode
+		Webhooks:       []Webhook{{URL: "https://hooks.example.com/alerts", Format: "discord"}},
+		Hooks:          []Hook{{Command: "notify-send alert"}},
 	}
 
 	cfg.validate()
@@ -53,6 +57,34 @@ func TestConfigValidation(t *testing.T) {
 	if cfg.PollInterval < time.Second {
 		t.Errorf("Poll interval should be adjusted to minimum 1s, got %v", cfg.PollInterval)
 	}
+
+	if cfg.RequestTimeout != DefaultRequestTimeout {
+		t.Errorf("Request timeout should default to %v, got %v", DefaultRequestTimeout, cfg.RequestTimeout)
+	}
+
+	if cfg.MaxPodDetails != DefaultMaxPodDetails {
+		t.Errorf("Max pod details should default to %d, got %d", DefaultMaxPodDetails, cfg.MaxPodDetails)
+	}
+
+	if cfg.LogTailLines != DefaultLogTailLines {
+		t.Errorf("Log tail lines should default to %d, got %d", DefaultLogTailLines, cfg.LogTailLines)
+	}
+
+	if cfg.ConnectionMode != ConnectionModeAuto {
+		t.Errorf("Unknown connection mode should fall back to '%s', got '%s'", ConnectionModeAuto, cfg.ConnectionMode)
+	}
+
+	if cfg.Hooks[0].Timeout != DefaultHookTimeout {
+		t.Errorf("Hook timeout should default to %v, got %v", DefaultHookTimeout, cfg.Hooks[0].Timeout)
+	}
+
+	if cfg.MaxConcurrentHooks != DefaultMaxConcurrentHooks {
+		t.Errorf("Max concurrent hooks should default to %d, got %d", DefaultMaxConcurrentHooks, cfg.MaxConcurrentHooks)
+	}
+
+	if webhook := cfg.Webhooks[0]; webhook.Format != WebhookFormatJSON || webhook.MinSeverity != SeverityWarning {
+		t.Errorf("Webhook should default to format '%s' and severity '%s', got %+v", WebhookFormatJSON, SeverityWarning, webhook)
+	}
 }
 
 func TestSaveAndLoad(t *testing.T) {
@@ -128,3 +160,66 @@ func TestGetDefaultKubeConfig(t *testing.T) {
 		t.Error("Default kubeconfig path should not be empty")
 	}
 }
+
+func TestKubeConfigPaths(t *testing.T) {
+	tempDir := t.TempDir()
+	configsDir := filepath.Join(tempDir, "configs")
+	if err := os.MkdirAll(filepath.Join(configsDir, "nested"), 0755); err != nil {
+		t.Fatalf("Failed to create configs dir: %v", err)
+	}
+	for _, name := range []string{"b.yaml", "a.yaml", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(configsDir, name), []byte{}, 0600); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	first := filepath.Join(tempDir, "first")
+	second := filepath.Join(tempDir, "second")
+	list := first + string(os.PathListSeparator) + second
+
+	tests := []struct {
+		name     string
+		cfg      Config
+		expected []string
+	}{
+		{
+			name:     "Single path",
+			cfg:      Config{KubeConfig: first},
+			expected: []string{first},
+		},
+		{
+			name:     "Path list keeps order",
+			cfg:      Config{KubeConfig: list},
+			expected: []string{first, second},
+		},
+		{
+			name: "Directory files follow the list",
+			cfg:  Config{KubeConfig: list, KubeConfigDir: configsDir},
+			expected: []string{
+				first, second,
+				filepath.Join(configsDir, "a.yaml"),
+				filepath.Join(configsDir, "b.yaml"),
+				filepath.Join(configsDir, "notes.txt"),
+			},
+		},
+		{
+			name:     "Glob pattern",
+			cfg:      Config{KubeConfigDir: filepath.Join(configsDir, "*.yaml")},
+			expected: []string{filepath.Join(configsDir, "a.yaml"), filepath.Join(configsDir, "b.yaml")},
+		},
+		{
+			name:     "Duplicates removed",
+			cfg:      Config{KubeConfig: first + string(os.PathListSeparator) + first},
+			expected: []string{first},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.cfg.KubeConfigPaths()
+			if strings.Join(result, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
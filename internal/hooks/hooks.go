@@ -0,0 +1,387 @@
+// Package hooks runs user commands when the cluster health or pods change
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mattlqx/k8s-tray/internal/config"
+	"github.com/mattlqx/k8s-tray/pkg/models"
+)
+
+// Hook execution limits
+const (
+	maxPendingHooks = 32              // Hooks waiting for a free slot; more are dropped
+	maxLoggedOutput = 4096            // Bytes of hook output written to the log
+	waitDelay       = 5 * time.Second // Wait for the output of processes left behind by a killed hook
+)
+
+// podDebounce is how long further pod events about a pod are held back after
+// hooks ran for it, so that a pod flapping between states runs them once for
+// its final state
+const podDebounce = 5 * time.Minute
+
+// Event is a change passed to hook commands as JSON on stdin
+type Event struct {
+	Type      string             `json:"event"` // config.HookEventHealth or config.HookEventPod
+	Context   string             `json:"context"`
+	Namespace string             `json:"namespace"`
+	OldHealth string             `json:"old_health"`
+	NewHealth string             `json:"new_health"`
+	Pods      []models.PodDetail `json:"pods"`              // Unhealthy pods for health events, pods that changed state for pod events
+	Changes   []models.Change    `json:"changes,omitempty"` // The changes behind the event
+	Time      time.Time          `json:"time"`
+
+	// Why the cluster cannot be reached, for the health event of losing the connection
+	Connection string `json:"connection,omitempty"`
+}
+
+// Events returns the hook events of a refresh: a health event when the health
+// transitioned and a pod event when pods moved to another state
+func Events(contextName, namespace string, prev, curr *models.ClusterStatus, changes []models.Change) []Event {
+	if prev == nil || curr == nil {
+		return nil
+	}
+
+	base := Event{
+		Context:   contextName,
+		Namespace: namespace,
+		OldHealth: prev.HealthStatus.String(),
+		NewHealth: curr.HealthStatus.String(),
+		Time:      curr.LastUpdated,
+	}
+
+	var details []models.PodDetail
+	if curr.PodStatus != nil {
+		details = curr.PodStatus.Details
+	}
+
+	var events []Event
+	if prev.HealthStatus != curr.HealthStatus {
+		event := base
+		event.Type = config.HookEventHealth
+		event.Changes = changes
+		event.Pods = unhealthyPods(curr)
+		events = append(events, event)
+	}
+
+	changed := make(map[string]bool)
+	event := base
+	event.Type = config.HookEventPod
+	for _, change := range changes {
+		if change.Kind == models.ChangePodState {
+			changed[change.Object] = true
+			event.Changes = append(event.Changes, change)
+		}
+	}
+	for _, pod := range details {
+		if changed[pod.Namespace+"/"+pod.Name] {
+			event.Pods = append(event.Pods, pod)
+		}
+	}
+	if len(event.Pods) > 0 {
+		events = append(events, event)
+	}
+
+	return events
+}
+
+// ConnectionLost returns the health event of losing the connection to the
+// cluster, from the health of last, the last status before the outage, to unknown
+func ConnectionLost(contextName, namespace string, last *models.ClusterStatus, connection models.ConnectionState, now time.Time) Event {
+	return Event{
+		Type:       config.HookEventHealth,
+		Context:    contextName,
+		Namespace:  namespace,
+		OldHealth:  last.HealthStatus.String(),
+		NewHealth:  models.HealthUnknown.String(),
+		Pods:       unhealthyPods(last),
+		Time:       now,
+		Connection: connection.String(),
+	}
+}
+
+// unhealthyPods returns the pods of the status that are not healthy
+func unhealthyPods(status *models.ClusterStatus) []models.PodDetail {
+	if status.PodStatus == nil {
+		return nil
+	}
+
+	var pods []models.PodDetail
+	for _, pod := range status.PodStatus.Details {
+		if pod.Category().Severity() != models.HealthHealthy {
+			pods = append(pods, pod)
+		}
+	}
+	return pods
+}
+
+// forHook returns the event as seen by a hook, keeping only the pods its
+// patterns match; false when a pod event has no pods left
+func (e Event) forHook(hook config.Hook) (Event, bool) {
+	if e.Type != config.HookEventPod || len(hook.Pods) == 0 {
+		return e, true
+	}
+	return e.withPods(func(key string) bool { return matchesAny(hook.Pods, key) })
+}
+
+// withPods returns the event with only the pods, and their changes, whose
+// namespace/name keep accepts; false when no pods are left
+func (e Event) withPods(keep func(key string) bool) (Event, bool) {
+	kept := make(map[string]bool)
+	var pods []models.PodDetail
+	for _, pod := range e.Pods {
+		if key := pod.Namespace + "/" + pod.Name; keep(key) {
+			kept[key] = true
+			pods = append(pods, pod)
+		}
+	}
+	if len(pods) == 0 {
+		return e, false
+	}
+
+	var changes []models.Change
+	for _, change := range e.Changes {
+		if kept[change.Object] {
+			changes = append(changes, change)
+		}
+	}
+
+	e.Pods, e.Changes = pods, changes
+	return e, true
+}
+
+// matchesAny reports whether name matches any of the glob patterns
+func matchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, err := path.Match(pattern, name); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// env returns the environment variables describing the event
+func (e Event) env() []string {
+	pods := make([]string, len(e.Pods))
+	for i, pod := range e.Pods {
+		pods[i] = pod.Namespace + "/" + pod.Name
+	}
+
+	return []string{
+		"K8S_TRAY_EVENT=" + e.Type,
+		"K8S_TRAY_CONTEXT=" + e.Context,
+		"K8S_TRAY_NAMESPACE=" + e.Namespace,
+		"K8S_TRAY_OLD_HEALTH=" + e.OldHealth,
+		"K8S_TRAY_NEW_HEALTH=" + e.NewHealth,
+		"K8S_TRAY_PODS=" + strings.Join(pods, " "),
+		"K8S_TRAY_CONNECTION=" + e.Connection,
+	}
+}
+
+// Runner runs hook commands in the background, a limited number at a time
+type Runner struct {
+	hooks       []config.Hook
+	slots       chan struct{}
+	pending     atomic.Int32 // Hooks started or waiting for a slot
+	wg          sync.WaitGroup
+	podDebounce time.Duration
+
+	mu   sync.Mutex
+	pods map[string]*podState // Pods hooks ran for within podDebounce, keyed by namespace/name
+}
+
+// podState is what hooks were last told about a pod
+type podState struct {
+	fired    time.Time // When hooks last ran for the pod
+	category models.PodCategory
+	held     *Event      // Latest pod event about only this pod, held back until timer fires
+	timer    *time.Timer // Releases the held event at the end of the debounce period
+}
+
+// New creates a runner for hooks running at most maxConcurrent commands at once
+func New(hooks []config.Hook, maxConcurrent int) *Runner {
+	if maxConcurrent <= 0 {
+		maxConcurrent = config.DefaultMaxConcurrentHooks
+	}
+	return &Runner{
+		hooks:       hooks,
+		slots:       make(chan struct{}, maxConcurrent),
+		podDebounce: podDebounce,
+		pods:        make(map[string]*podState),
+	}
+}
+
+// Fire starts the hooks handling the event in the background. Pods that hooks
+// ran for within the debounce period are left out of pod events and handled
+// at its end, if their state still differs from the one hooks last got.
+func (r *Runner) Fire(ctx context.Context, event Event) {
+	if event.Type == config.HookEventPod {
+		var ok bool
+		if event, ok = r.debounce(ctx, event); !ok {
+			return
+		}
+	}
+	r.start(ctx, event)
+}
+
+// debounce returns the pod event without the pods hooks ran for within the
+// debounce period, holding those back; false when no pods are left
+func (r *Runner) debounce(ctx context.Context, event Event) (Event, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for key, state := range r.pods {
+		if state.timer == nil && now.Sub(state.fired) >= r.podDebounce {
+			delete(r.pods, key)
+		}
+	}
+
+	held := make(map[string]bool)
+	for _, pod := range event.Pods {
+		key := pod.Namespace + "/" + pod.Name
+		state, ok := r.pods[key]
+		if !ok || now.Sub(state.fired) >= r.podDebounce {
+			r.pods[key] = &podState{fired: now, category: pod.Category()}
+			continue
+		}
+
+		podEvent, _ := event.withPods(func(k string) bool { return k == key })
+		if state.held != nil {
+			podEvent.OldHealth = state.held.OldHealth
+			podEvent.Changes = append(append([]models.Change(nil), state.held.Changes...), podEvent.Changes...)
+		}
+		state.held = &podEvent
+		if state.timer == nil {
+			state.timer = time.AfterFunc(state.fired.Add(r.podDebounce).Sub(now), func() { r.release(ctx, key) })
+		}
+		held[key] = true
+	}
+
+	return event.withPods(func(key string) bool { return !held[key] })
+}
+
+// release starts the hooks for the pod event held back during the debounce
+// period, unless the pod is back in the state hooks were last told about
+func (r *Runner) release(ctx context.Context, key string) {
+	r.mu.Lock()
+	state, ok := r.pods[key]
+	if !ok || state.held == nil {
+		r.mu.Unlock()
+		return
+	}
+	event := *state.held
+	state.held, state.timer = nil, nil
+	category := event.Pods[0].Category()
+	changed := category != state.category
+	if changed {
+		state.fired, state.category = time.Now(), category
+	}
+	r.mu.Unlock()
+
+	if changed && ctx.Err() == nil {
+		r.start(ctx, event)
+	}
+}
+
+// start starts the hooks handling the event in the background
+func (r *Runner) start(ctx context.Context, event Event) {
+	for _, hook := range r.hooks {
+		if !hook.Handles(event.Type) {
+			continue
+		}
+		hookEvent, ok := event.forHook(hook)
+		if !ok {
+			continue
+		}
+
+		if r.pending.Add(1) > maxPendingHooks {
+			r.pending.Add(-1)
+			log.Printf("Skipping hook %q for %s event, too many hooks pending", hook.Command, event.Type)
+			continue
+		}
+
+		r.wg.Add(1)
+		go func(hook config.Hook) {
+			defer r.wg.Done()
+			defer r.pending.Add(-1)
+
+			select {
+			case r.slots <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			defer func() { <-r.slots }()
+
+			if err := run(ctx, hook, hookEvent); err != nil {
+				log.Printf("Hook for %s event failed: %v", hookEvent.Type, err)
+			}
+		}(hook)
+	}
+}
+
+// Wait blocks until all started hooks have finished
+func (r *Runner) Wait() {
+	r.wg.Wait()
+}
+
+// run runs a hook command with the event in its environment and on stdin,
+// logging its output
+func run(ctx context.Context, hook config.Hook, event Event) error {
+	input, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", event.Type, err)
+	}
+
+	timeout := hook.Timeout
+	if timeout <= 0 {
+		timeout = config.DefaultHookTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := shellCommand(ctx, hook.Command)
+	cmd.Env = append(os.Environ(), event.env()...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.WaitDelay = waitDelay
+	killTree(cmd)
+
+	output, err := cmd.CombinedOutput()
+	if len(output) > 0 {
+		if len(output) > maxLoggedOutput {
+			output = append(output[:maxLoggedOutput:maxLoggedOutput], "…"...)
+		}
+		log.Printf("Hook %q output:\n%s", hook.Command, output)
+	}
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("hook %q timed out after %s", hook.Command, timeout)
+	}
+	if err != nil {
+		return fmt.Errorf("hook %q failed: %w", hook.Command, err)
+	}
+	return nil
+}
+
+// shellCommand runs a command line through the platform's shell
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		// #nosec G204 -- the command comes from the user's own configuration
+		return exec.CommandContext(ctx, "cmd", "/c", command)
+	}
+	// #nosec G204 -- the command comes from the user's own configuration
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
@@ -0,0 +1,283 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattlqx/k8s-tray/internal/config"
+	"github.com/mattlqx/k8s-tray/pkg/models"
+)
+
+var (
+	readyAPI    = models.PodDetail{Name: "api", Namespace: "default", Phase: "Running", Ready: true}
+	crashingAPI = models.PodDetail{Name: "api", Namespace: "default", Phase: "Running", Issue: models.PodIssueCrashLoop}
+	readyWorker = models.PodDetail{Name: "worker", Namespace: "jobs", Phase: "Running", Ready: true}
+)
+
+// hookStatus creates a status with the given health and pods
+func hookStatus(health models.HealthStatus, pods ...models.PodDetail) *models.ClusterStatus {
+	return &models.ClusterStatus{
+		ClusterName:  "prod",
+		HealthStatus: health,
+		PodStatus:    &models.PodStatus{Total: len(pods), Details: pods},
+		LastUpdated:  time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+// skipOnWindows skips tests whose hook commands are written for sh
+func skipOnWindows(t *testing.T) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("Hook commands are run through sh in this test")
+	}
+}
+
+func TestEvents(t *testing.T) {
+	tests := []struct {
+		name     string
+		prev     *models.ClusterStatus
+		curr     *models.ClusterStatus
+		expected []string // Type and pods of each event
+	}{
+		{name: "First refresh", prev: nil, curr: hookStatus(models.HealthCritical, crashingAPI), expected: nil},
+		{name: "No change", prev: hookStatus(models.HealthHealthy, readyAPI), curr: hookStatus(models.HealthHealthy, readyAPI), expected: nil},
+		{
+			name:     "Pod entered CrashLoopBackOff",
+			prev:     hookStatus(models.HealthHealthy, readyAPI, readyWorker),
+			curr:     hookStatus(models.HealthCritical, crashingAPI, readyWorker),
+			expected: []string{"health default/api", "pod default/api"},
+		},
+		{
+			name:     "Recovered",
+			prev:     hookStatus(models.HealthCritical, crashingAPI, readyWorker),
+			curr:     hookStatus(models.HealthHealthy, readyAPI, readyWorker),
+			expected: []string{"health", "pod default/api"},
+		},
+		{
+			name:     "Pod added",
+			prev:     hookStatus(models.HealthHealthy, readyAPI),
+			curr:     hookStatus(models.HealthHealthy, readyAPI, readyWorker),
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result []string
+			for _, event := range Events("prod", config.AllNamespaces, tt.prev, tt.curr, models.Diff(tt.prev, tt.curr)) {
+				result = append(result, strings.TrimSpace(event.Type+" "+strings.Join(podNames(event.Pods), " ")))
+				if event.Context != "prod" || event.OldHealth != tt.prev.HealthStatus.String() || event.NewHealth != tt.curr.HealthStatus.String() {
+					t.Errorf("Unexpected event %+v", event)
+				}
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+// podNames returns the namespace/name of each pod
+func podNames(pods []models.PodDetail) []string {
+	names := make([]string, len(pods))
+	for i, pod := range pods {
+		names[i] = pod.Namespace + "/" + pod.Name
+	}
+	return names
+}
+
+func TestEvent_ForHook(t *testing.T) {
+	event := Event{
+		Type: config.HookEventPod,
+		Pods: []models.PodDetail{crashingAPI, readyWorker},
+		Changes: []models.Change{
+			{Kind: models.ChangePodState, Object: "default/api"},
+			{Kind: models.ChangePodState, Object: "jobs/worker"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		patterns []string
+		expected []string
+	}{
+		{name: "All pods", patterns: nil, expected: []string{"default/api", "jobs/worker"}},
+		{name: "Namespace glob", patterns: []string{"jobs/*"}, expected: []string{"jobs/worker"}},
+		{name: "No match", patterns: []string{"kube-system/*"}, expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, ok := event.forHook(config.Hook{Pods: tt.patterns})
+			if ok != (tt.expected != nil) {
+				t.Fatalf("Expected match %v, got %v", tt.expected != nil, ok)
+			}
+			if !ok {
+				return
+			}
+			if names := podNames(result.Pods); !reflect.DeepEqual(names, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, names)
+			}
+			if len(result.Changes) != len(tt.expected) {
+				t.Errorf("Expected %d changes, got %+v", len(tt.expected), result.Changes)
+			}
+		})
+	}
+}
+
+func TestRunner_Fire(t *testing.T) {
+	skipOnWindows(t)
+	dir := t.TempDir()
+
+	runner := New([]config.Hook{
+		{Command: `cat > "$OUT/event.json"; echo "$K8S_TRAY_EVENT $K8S_TRAY_OLD_HEALTH $K8S_TRAY_NEW_HEALTH $K8S_TRAY_PODS" > "$OUT/env"`},
+		{Command: `touch "$OUT/health-only"`, On: []string{config.HookEventHealth}},
+		{Command: `touch "$OUT/other-pods"`, Pods: []string{"kube-system/*"}},
+	}, 2)
+	t.Setenv("OUT", dir)
+
+	prev := hookStatus(models.HealthHealthy, readyAPI)
+	curr := hookStatus(models.HealthCritical, crashingAPI)
+	runner.Fire(context.Background(), Events("prod", "default", prev, curr, models.Diff(prev, curr))[1])
+	runner.Wait()
+
+	env, err := os.ReadFile(filepath.Join(dir, "env"))
+	if err != nil {
+		t.Fatalf("Hook did not run: %v", err)
+	}
+	if expected := "pod Healthy Critical default/api\n"; string(env) != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, env)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "event.json"))
+	if err != nil {
+		t.Fatalf("Failed to read event: %v", err)
+	}
+	var event Event
+	if err := json.Unmarshal(data, &event); err != nil {
+		t.Fatalf("Failed to decode event %s: %v", data, err)
+	}
+	if event.Namespace != "default" || len(event.Pods) != 1 || event.Pods[0].Issue != models.PodIssueCrashLoop {
+		t.Errorf("Unexpected event %+v", event)
+	}
+
+	for _, skipped := range []string{"health-only", "other-pods"} {
+		if _, err := os.Stat(filepath.Join(dir, skipped)); err == nil {
+			t.Errorf("Hook %s should not have run for a pod event", skipped)
+		}
+	}
+}
+
+func TestRunner_Debounce(t *testing.T) {
+	skipOnWindows(t)
+
+	healthy := hookStatus(models.HealthHealthy, readyAPI)
+	critical := hookStatus(models.HealthCritical, crashingAPI)
+	podEvent := func(prev, curr *models.ClusterStatus) Event {
+		events := Events("prod", "default", prev, curr, models.Diff(prev, curr))
+		return events[len(events)-1]
+	}
+
+	tests := []struct {
+		name     string
+		events   []Event
+		expected string // The health after each hook run
+	}{
+		{name: "Recovered during the debounce period", events: []Event{podEvent(healthy, critical), podEvent(critical, healthy)}, expected: "Critical\nHealthy\n"},
+		{name: "Flapped back", events: []Event{podEvent(healthy, critical), podEvent(critical, healthy), podEvent(healthy, critical)}, expected: "Critical\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			t.Setenv("OUT", dir)
+			runner := New([]config.Hook{{Command: `echo "$K8S_TRAY_NEW_HEALTH" >> "$OUT/runs"`}}, 1)
+			runner.podDebounce = 50 * time.Millisecond
+
+			for _, event := range tt.events {
+				runner.Fire(context.Background(), event)
+			}
+
+			// Held events are handled at the end of the debounce period
+			time.Sleep(200 * time.Millisecond)
+			runner.Wait()
+
+			runs, _ := os.ReadFile(filepath.Join(dir, "runs"))
+			if string(runs) != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, runs)
+			}
+		})
+	}
+}
+
+func TestConnectionLost(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 5, 0, 0, time.UTC)
+	event := ConnectionLost("prod", "default", hookStatus(models.HealthWarning, crashingAPI, readyWorker), models.ConnectionUnreachable, now)
+
+	if event.Type != config.HookEventHealth || event.OldHealth != "Warning" || event.NewHealth != "Unknown" || !event.Time.Equal(now) {
+		t.Errorf("Unexpected event %+v", event)
+	}
+	if names := podNames(event.Pods); !reflect.DeepEqual(names, []string{"default/api"}) {
+		t.Errorf("Expected the unhealthy pods before the outage, got %v", names)
+	}
+	env := strings.Join(event.env(), "\n")
+	if !strings.Contains(env, "K8S_TRAY_CONNECTION=Unreachable") {
+		t.Errorf("Expected the connection state in the environment, got %s", env)
+	}
+}
+
+func TestRunner_ConcurrencyLimit(t *testing.T) {
+	skipOnWindows(t)
+	dir := t.TempDir()
+	t.Setenv("OUT", dir)
+
+	// mkdir fails when another hook holds the lock
+	hook := config.Hook{Command: `mkdir "$OUT/lock" || touch "$OUT/overlap"; sleep 0.05; rmdir "$OUT/lock"`}
+	runner := New([]config.Hook{hook, hook, hook}, 1)
+	runner.Fire(context.Background(), Event{Type: config.HookEventHealth})
+	runner.Wait()
+
+	if _, err := os.Stat(filepath.Join(dir, "overlap")); err == nil {
+		t.Error("Expected hooks to run one at a time")
+	}
+}
+
+func TestRun(t *testing.T) {
+	skipOnWindows(t)
+
+	tests := []struct {
+		name     string
+		hook     config.Hook
+		expected string // Part of the error; empty for success
+	}{
+		{name: "Success", hook: config.Hook{Command: "echo paged"}},
+		{name: "Failure", hook: config.Hook{Command: "exit 3"}, expected: "exit status 3"},
+		{name: "Timeout", hook: config.Hook{Command: "sleep 5", Timeout: 50 * time.Millisecond}, expected: "timed out after 50ms"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Now()
+			err := run(context.Background(), tt.hook, Event{Type: config.HookEventHealth})
+			if tt.expected == "" {
+				if err != nil {
+					t.Errorf("Expected success, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Expected error containing '%s', got %v", tt.expected, err)
+			}
+			if elapsed := time.Since(start); elapsed > 2*time.Second {
+				t.Errorf("Hook ran for %s despite its timeout", elapsed)
+			}
+		})
+	}
+}
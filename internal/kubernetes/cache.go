@@ -0,0 +1,427 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	batchlisters "k8s.io/client-go/listers/batch/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/mattlqx/k8s-tray/internal/config"
+	"github.com/mattlqx/k8s-tray/pkg/models"
+)
+
+// DefaultWatchDebounce is how long Watch waits for further changes before recomputing status
+const DefaultWatchDebounce = 2 * time.Second
+
+// maxWatchLatency is how many debounce periods a change may wait while further
+// changes keep arriving, so that a busy cluster still gets status updates
+const maxWatchLatency = 5
+
+// clusterCache keeps pods, nodes, namespaces, workloads and jobs current using shared informers
+type clusterCache struct {
+	factory      informers.SharedInformerFactory
+	pods         corelisters.PodLister
+	nodes        corelisters.NodeLister
+	namespaces   corelisters.NamespaceLister
+	deployments  appslisters.DeploymentLister
+	statefulSets appslisters.StatefulSetLister
+	daemonSets   appslisters.DaemonSetLister
+	jobs         batchlisters.JobLister
+	cronJobs     batchlisters.CronJobLister
+	hasSynced    []cache.InformerSynced
+
+	// features whose resources are cached; the listers of the others are nil
+	features map[models.Feature]bool
+
+	// changed receives a signal whenever any watched object changes
+	changed chan struct{}
+}
+
+// newClusterCache creates the informers backing the cache without starting them.
+// Pods and nodes are always cached; namespaces, workloads and jobs only when
+// features allows them.
+func newClusterCache(clientset kubernetes.Interface, features map[models.Feature]bool) (*clusterCache, error) {
+	// Resync is disabled; the tray's poll interval acts as the periodic fallback instead
+	factory := informers.NewSharedInformerFactory(clientset, 0)
+
+	c := &clusterCache{
+		factory:  factory,
+		changed:  make(chan struct{}, 1),
+		features: map[models.Feature]bool{models.FeaturePods: true, models.FeatureNodes: true},
+	}
+
+	podInformer := factory.Core().V1().Pods()
+	nodeInformer := factory.Core().V1().Nodes()
+	c.pods = podInformer.Lister()
+	c.nodes = nodeInformer.Lister()
+	cached := []cache.SharedIndexInformer{podInformer.Informer(), nodeInformer.Informer()}
+
+	if features[models.FeatureNamespaces] {
+		namespaceInformer := factory.Core().V1().Namespaces()
+		c.namespaces = namespaceInformer.Lister()
+		cached = append(cached, namespaceInformer.Informer())
+		c.features[models.FeatureNamespaces] = true
+	}
+	if features[models.FeatureWorkloads] {
+		deploymentInformer := factory.Apps().V1().Deployments()
+		statefulSetInformer := factory.Apps().V1().StatefulSets()
+		daemonSetInformer := factory.Apps().V1().DaemonSets()
+		c.deployments = deploymentInformer.Lister()
+		c.statefulSets = statefulSetInformer.Lister()
+		c.daemonSets = daemonSetInformer.Lister()
+		cached = append(cached, deploymentInformer.Informer(), statefulSetInformer.Informer(), daemonSetInformer.Informer())
+		c.features[models.FeatureWorkloads] = true
+	}
+	if features[models.FeatureJobs] {
+		jobInformer := factory.Batch().V1().Jobs()
+		cronJobInformer := factory.Batch().V1().CronJobs()
+		c.jobs = jobInformer.Lister()
+		c.cronJobs = cronJobInformer.Lister()
+		cached = append(cached, jobInformer.Informer(), cronJobInformer.Informer())
+		c.features[models.FeatureJobs] = true
+	}
+
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { c.notify() },
+		UpdateFunc: c.onUpdate,
+		DeleteFunc: func(interface{}) { c.notify() },
+	}
+
+	for _, informer := range cached {
+		// Drop managed fields to keep the memory footprint small on large clusters;
+		// every pod in the cluster is held, so those keep only what the status needs
+		transform := stripManagedFields
+		if informer == podInformer.Informer() {
+			transform = slimPod
+		}
+		if err := informer.SetTransform(transform); err != nil {
+			return nil, err
+		}
+		if _, err := informer.AddEventHandler(handler); err != nil {
+			return nil, err
+		}
+		c.hasSynced = append(c.hasSynced, informer.HasSynced)
+	}
+
+	return c, nil
+}
+
+// serves reports whether the cache holds the resources of feature; a nil cache holds none
+func (c *clusterCache) serves(feature models.Feature) bool {
+	return c != nil && c.features[feature]
+}
+
+// start starts the informers; they run until ctx is cancelled
+func (c *clusterCache) start(ctx context.Context) {
+	c.factory.Start(ctx.Done())
+}
+
+// waitForSync blocks until all informers have synced or ctx is cancelled
+func (c *clusterCache) waitForSync(ctx context.Context) bool {
+	return cache.WaitForCacheSync(ctx.Done(), c.hasSynced...)
+}
+
+// synced reports whether all informers have completed their initial list
+func (c *clusterCache) synced() bool {
+	for _, hasSynced := range c.hasSynced {
+		if !hasSynced() {
+			return false
+		}
+	}
+	return true
+}
+
+// shutdown stops the informers and waits for them to exit
+func (c *clusterCache) shutdown() {
+	c.factory.Shutdown()
+}
+
+// listPods returns cached pods in the namespace ("" for all namespaces)
+func (c *clusterCache) listPods(namespace string) ([]*corev1.Pod, error) {
+	if namespace == "" {
+		return c.pods.List(labels.Everything())
+	}
+	return c.pods.Pods(namespace).List(labels.Everything())
+}
+
+// listNodes returns all cached nodes
+func (c *clusterCache) listNodes() ([]*corev1.Node, error) {
+	return c.nodes.List(labels.Everything())
+}
+
+// listNamespaces returns all cached namespaces
+func (c *clusterCache) listNamespaces() ([]*corev1.Namespace, error) {
+	return c.namespaces.List(labels.Everything())
+}
+
+// listDeployments returns cached deployments in the namespace ("" for all namespaces)
+func (c *clusterCache) listDeployments(namespace string) ([]*appsv1.Deployment, error) {
+	if namespace == "" {
+		return c.deployments.List(labels.Everything())
+	}
+	return c.deployments.Deployments(namespace).List(labels.Everything())
+}
+
+// listStatefulSets returns cached stateful sets in the namespace ("" for all namespaces)
+func (c *clusterCache) listStatefulSets(namespace string) ([]*appsv1.StatefulSet, error) {
+	if namespace == "" {
+		return c.statefulSets.List(labels.Everything())
+	}
+	return c.statefulSets.StatefulSets(namespace).List(labels.Everything())
+}
+
+// listDaemonSets returns cached daemon sets in the namespace ("" for all namespaces)
+func (c *clusterCache) listDaemonSets(namespace string) ([]*appsv1.DaemonSet, error) {
+	if namespace == "" {
+		return c.daemonSets.List(labels.Everything())
+	}
+	return c.daemonSets.DaemonSets(namespace).List(labels.Everything())
+}
+
+// listJobs returns cached jobs in the namespace ("" for all namespaces)
+func (c *clusterCache) listJobs(namespace string) ([]*batchv1.Job, error) {
+	if namespace == "" {
+		return c.jobs.List(labels.Everything())
+	}
+	return c.jobs.Jobs(namespace).List(labels.Everything())
+}
+
+// listCronJobs returns cached cron jobs in the namespace ("" for all namespaces)
+func (c *clusterCache) listCronJobs(namespace string) ([]*batchv1.CronJob, error) {
+	if namespace == "" {
+		return c.cronJobs.List(labels.Everything())
+	}
+	return c.cronJobs.CronJobs(namespace).List(labels.Everything())
+}
+
+// onUpdate signals a change unless the object is unchanged (e.g. a relist)
+func (c *clusterCache) onUpdate(oldObj, newObj interface{}) {
+	oldMeta, oldErr := meta.Accessor(oldObj)
+	newMeta, newErr := meta.Accessor(newObj)
+	if oldErr == nil && newErr == nil && oldMeta.GetResourceVersion() == newMeta.GetResourceVersion() {
+		return
+	}
+	c.notify()
+}
+
+// notify records that something changed without blocking the informer
+func (c *clusterCache) notify() {
+	select {
+	case c.changed <- struct{}{}:
+	default:
+		// A change is already pending
+	}
+}
+
+// stripManagedFields removes managed fields from cached objects
+func stripManagedFields(obj interface{}) (interface{}, error) {
+	if accessor, err := meta.Accessor(obj); err == nil {
+		accessor.SetManagedFields(nil)
+	}
+	return obj, nil
+}
+
+// slimPod reduces cached pods to the fields the cluster status reads: identity,
+// container names and resources, and the status. Logs fetch the full pod.
+func slimPod(obj interface{}) (interface{}, error) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return stripManagedFields(obj)
+	}
+
+	slim := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              pod.Name,
+			Namespace:         pod.Namespace,
+			UID:               pod.UID,
+			ResourceVersion:   pod.ResourceVersion,
+			CreationTimestamp: pod.CreationTimestamp,
+			DeletionTimestamp: pod.DeletionTimestamp,
+		},
+		Spec: corev1.PodSpec{NodeName: pod.Spec.NodeName},
+		Status: corev1.PodStatus{
+			Phase:                 pod.Status.Phase,
+			Reason:                pod.Status.Reason,
+			Conditions:            pod.Status.Conditions,
+			InitContainerStatuses: pod.Status.InitContainerStatuses,
+			ContainerStatuses:     pod.Status.ContainerStatuses,
+		},
+	}
+	// The controller decides whether a failed pod is judged through its Job
+	if owner := metav1.GetControllerOf(pod); owner != nil {
+		slim.OwnerReferences = []metav1.OwnerReference{*owner}
+	}
+	for _, container := range pod.Spec.InitContainers {
+		slim.Spec.InitContainers = append(slim.Spec.InitContainers, corev1.Container{Name: container.Name, Resources: container.Resources})
+	}
+	for _, container := range pod.Spec.Containers {
+		slim.Spec.Containers = append(slim.Spec.Containers, corev1.Container{Name: container.Name, Resources: container.Resources})
+	}
+	return slim, nil
+}
+
+// Watch starts watch-driven updates of the cluster status. Informers keep pods,
+// nodes, namespaces, workloads and jobs current, and a recomputed status is delivered on the
+// returned channel after changes settle for the debounce period, or at the
+// latest maxWatchLatency debounce periods after the first change. Until the
+// informers have synced, the client falls back to listing from the API server.
+// The channel is closed when ctx is cancelled.
+func (c *Client) Watch(ctx context.Context, debounce time.Duration) (<-chan *models.ClusterStatus, error) {
+	reqCtx, cancel := c.requestContext(ctx)
+	features, err := c.watchedFeatures(reqCtx)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+
+	cc, err := newClusterCache(c.clientset, features)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(chan *models.ClusterStatus, 1)
+	cc.start(ctx)
+
+	go func() {
+		defer close(updates)
+		defer func() {
+			c.setCache(nil)
+			cc.shutdown()
+		}()
+
+		if !cc.waitForSync(ctx) {
+			return
+		}
+		c.setCache(cc)
+		log.Printf("Informer cache synced")
+
+		// Drop the changes seen during the initial sync and publish a full status
+		select {
+		case <-cc.changed:
+		default:
+		}
+		c.publishStatus(ctx, updates)
+
+		timer := time.NewTimer(debounce)
+		timer.Stop()
+		defer timer.Stop()
+
+		var pendingSince time.Time // First change not yet published
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-cc.changed:
+				if pendingSince.IsZero() {
+					pendingSince = time.Now()
+				}
+				// Restart the debounce window on every change, up to the max latency
+				deadline := pendingSince.Add(maxWatchLatency * debounce)
+				timer.Reset(min(debounce, max(time.Until(deadline), 0)))
+			case <-timer.C:
+				pendingSince = time.Time{}
+				c.publishStatus(ctx, updates)
+			}
+		}
+	}()
+
+	return updates, nil
+}
+
+// watchedFeatures returns the features whose resources informers may list in
+// all namespaces. Informers never sync without permission to list, so pods
+// and nodes are required and the resources of other features the user may
+// not list cluster-wide keep being listed from the API server.
+func (c *Client) watchedFeatures(ctx context.Context) (map[models.Feature]bool, error) {
+	features := map[models.Feature]bool{
+		models.FeatureNamespaces: true,
+		models.FeatureWorkloads:  true,
+		models.FeatureJobs:       true,
+	}
+
+	perms := c.ensurePermissions(ctx)
+	if perms == nil {
+		// The permission check failed; assume everything is allowed
+		return features, nil
+	}
+
+	// Resource usage is denied when pods cannot be listed in all namespaces
+	if !perms.Allowed(models.FeaturePods) || !perms.Allowed(models.FeatureNodes) || !perms.Allowed(models.FeatureResources) {
+		return nil, fmt.Errorf("watching requires permission to list nodes and pods in all namespaces")
+	}
+
+	probe := &permissionProbe{client: c, rules: make(map[string]*authorizationv1.SubjectRulesReviewStatus)}
+	for feature := range features {
+		if !perms.Allowed(feature) {
+			features[feature] = false
+			continue
+		}
+		// Namespaced features were probed in the monitored namespace only
+		if feature == models.FeatureNamespaces || c.config.Namespace == config.AllNamespaces {
+			continue
+		}
+		allowed, err := probe.canListAny(ctx, []string{""}, featureResources[feature])
+		if err != nil {
+			return nil, fmt.Errorf("failed to check permissions: %w", err)
+		}
+		features[feature] = allowed
+	}
+
+	for feature, cached := range features {
+		if !cached {
+			log.Printf("Not watching %s, listing them on each refresh instead", feature)
+		}
+	}
+	return features, nil
+}
+
+// publishStatus recomputes the cluster status and replaces any undelivered update
+func (c *Client) publishStatus(ctx context.Context, updates chan *models.ClusterStatus) {
+	status, err := c.GetClusterStatus(ctx)
+	if err != nil {
+		log.Printf("Failed to compute cluster status from cache: %v", err)
+		return
+	}
+
+	select {
+	case <-updates:
+		// Discard the stale update the consumer has not picked up yet
+	default:
+	}
+
+	select {
+	case updates <- status:
+	default:
+	}
+}
+
+// setCache swaps the informer cache used to serve reads
+func (c *Client) setCache(cc *clusterCache) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache = cc
+}
+
+// syncedCache returns the informer cache if it is ready to serve reads
+func (c *Client) syncedCache() *clusterCache {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.cache != nil && c.cache.synced() {
+		return c.cache
+	}
+	return nil
+}
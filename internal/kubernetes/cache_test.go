@@ -0,0 +1,185 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/mattlqx/k8s-tray/pkg/models"
+)
+
+// nextUpdate waits up to timeout for the next status delivered by Watch
+func nextUpdate(t *testing.T, updates <-chan *models.ClusterStatus, timeout time.Duration) *models.ClusterStatus {
+	t.Helper()
+	select {
+	case status := <-updates:
+		return status
+	case <-time.After(timeout):
+		return nil
+	}
+}
+
+// createPods creates pods named prefix-0 to prefix-(count-1), waiting interval between them
+func createPods(t *testing.T, client *Client, prefix string, count int, interval time.Duration) {
+	t.Helper()
+	for i := 0; i < count; i++ {
+		pod := newTestPod("default", fmt.Sprintf("%s-%d", prefix, i), corev1.PodRunning, true)
+		if _, err := client.clientset.CoreV1().Pods("default").Create(context.Background(), pod, metav1.CreateOptions{}); err != nil {
+			t.Errorf("Failed to create pod: %v", err)
+			return
+		}
+		time.Sleep(interval)
+	}
+}
+
+func TestWatch_Synced(t *testing.T) {
+	client := newTestClient("default", newTestPod("default", "web", corev1.PodRunning, true))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	updates, err := client.Watch(ctx, time.Millisecond)
+	if err != nil {
+		t.Fatalf("Failed to start watching: %v", err)
+	}
+
+	// The first status is published once the informers have synced
+	status := nextUpdate(t, updates, 5*time.Second)
+	if status == nil {
+		t.Fatal("Timed out waiting for the first status")
+	}
+	if status.PodStatus.Total != 1 {
+		t.Errorf("Expected 1 pod, got %d", status.PodStatus.Total)
+	}
+	if client.syncedCache() == nil {
+		t.Error("Expected the informer cache to serve reads")
+	}
+
+	// Reads served from the cache see new pods without listing them
+	clientset := client.clientset.(*fake.Clientset)
+	clientset.ClearActions()
+	createPods(t, client, "api", 1, 0)
+	if status := nextUpdate(t, updates, 5*time.Second); status == nil || status.PodStatus.Total != 2 {
+		t.Errorf("Expected an update with 2 pods, got %+v", status)
+	}
+	for _, action := range clientset.Actions() {
+		if action.GetVerb() == "list" && action.GetResource().Resource == "pods" {
+			t.Error("Expected pods to be read from the cache")
+		}
+	}
+
+	// Cancelling stops the informers, closes the channel and falls back to listing
+	cancel()
+	for status := range updates {
+		t.Logf("Drained update with %d pods", status.PodStatus.Total)
+	}
+	if client.syncedCache() != nil {
+		t.Error("Expected the cache to be dropped after watching stopped")
+	}
+}
+
+func TestWatch_ListForbidden(t *testing.T) {
+	client := newTestClient("default", newTestPod("default", "web", corev1.PodRunning, true))
+
+	// Access reviews allow jobs, but listing them is denied
+	clientset := client.clientset.(*fake.Clientset)
+	clientset.PrependReactor("list", "jobs", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Group: "batch", Resource: "jobs"}, "", errors.New("denied"))
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	updates, err := client.Watch(ctx, time.Millisecond)
+	if err != nil {
+		t.Fatalf("Failed to start watching: %v", err)
+	}
+
+	// The job informer never syncs, so nothing is published from the cache
+	if status := nextUpdate(t, updates, 200*time.Millisecond); status != nil {
+		t.Errorf("Expected no status before the informers sync, got %+v", status)
+	}
+	if client.syncedCache() != nil {
+		t.Fatal("Expected the cache not to serve reads")
+	}
+
+	// Refreshes list from the API server instead
+	status, err := client.GetClusterStatus(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to get cluster status: %v", err)
+	}
+	if status.PodStatus.Total != 1 {
+		t.Errorf("Expected 1 pod, got %d", status.PodStatus.Total)
+	}
+	if status.Jobs != nil {
+		t.Errorf("Expected no job status, got %+v", status.Jobs)
+	}
+}
+
+func TestWatch_Debounce(t *testing.T) {
+	client := newTestClient("default")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	debounce := 100 * time.Millisecond
+	updates, err := client.Watch(ctx, debounce)
+	if err != nil {
+		t.Fatalf("Failed to start watching: %v", err)
+	}
+	if nextUpdate(t, updates, 5*time.Second) == nil {
+		t.Fatal("Timed out waiting for the first status")
+	}
+
+	// A burst of changes is published once, after it settles
+	createPods(t, client, "burst", 5, 0)
+	status := nextUpdate(t, updates, 5*time.Second)
+	if status == nil {
+		t.Fatal("Timed out waiting for the update")
+	}
+	if status.PodStatus.Total != 5 {
+		t.Errorf("Expected 5 pods in one update, got %d", status.PodStatus.Total)
+	}
+	if status := nextUpdate(t, updates, 3*debounce); status != nil {
+		t.Errorf("Expected the burst to be published once, got another update with %d pods", status.PodStatus.Total)
+	}
+}
+
+func TestWatch_MaxLatency(t *testing.T) {
+	client := newTestClient("default")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	debounce := 50 * time.Millisecond
+	updates, err := client.Watch(ctx, debounce)
+	if err != nil {
+		t.Fatalf("Failed to start watching: %v", err)
+	}
+	if nextUpdate(t, updates, 5*time.Second) == nil {
+		t.Fatal("Timed out waiting for the first status")
+	}
+
+	// Changes keep arriving within the debounce period for far longer than
+	// the latency ceiling, yet updates are still published meanwhile
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		createPods(t, client, "busy", 100, debounce/5)
+	}()
+	defer func() { <-done }()
+
+	select {
+	case status := <-updates:
+		if status.PodStatus.Total >= 100 {
+			t.Error("Expected an update before the changes stopped")
+		}
+	case <-done:
+		t.Error("Expected an update before the changes stopped")
+	}
+}
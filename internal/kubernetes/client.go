@@ -2,14 +2,19 @@ package kubernetes
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"sync"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
-	"k8s.io/client-go/tools/clientcmd"
+	metricsclientset "k8s.io/metrics/pkg/client/clientset/versioned"
 
 	"github.com/mattlqx/k8s-tray/internal/config"
 	"github.com/mattlqx/k8s-tray/pkg/models"
@@ -17,155 +22,442 @@ import (
 
 // Client wraps the Kubernetes client with additional functionality
 type Client struct {
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
 	config    *config.Config
 	namespace string
-}
 
-// NewClient creates a new Kubernetes client
-func NewClient(cfg *config.Config) (*Client, error) {
-	// Build config from kubeconfig
-	config, err := buildConfig(cfg.KubeConfig, cfg.Context)
-	if err != nil {
-		return nil, fmt.Errorf("failed to build config: %w", err)
-	}
+	// Context the client was built for; empty when not built from a kubeconfig
+	contextName string
 
-	// Create clientset
-	clientset, err := kubernetes.NewForConfig(config)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create clientset: %w", err)
-	}
+	// Exec plugin or auth provider supplying credentials; empty for static credentials
+	credentialPlugin string
 
+	// Default namespace of the kubeconfig context, monitored when the user may
+	// not list all namespaces
+	defaultNamespace string
+
+	// Client for the metrics.k8s.io API served by metrics-server
+	metrics metricsclientset.Interface
+
+	// Informer cache, set while Watch is running and synced
+	mu    sync.RWMutex
+	cache *clusterCache
+
+	// RBAC permissions, probed for the namespace they were checked in; guarded by mu
+	permissions          *models.Permissions
+	permissionsNamespace string
+
+	// Server version, cached between refreshes; guarded by mu
+	version          *version.Info
+	versionFetchedAt time.Time
+
+	// Metrics API availability as last observed; guarded by mu
+	metricsChecked   bool
+	metricsAvailable bool
+	metricsCheckedAt time.Time
+}
+
+// NewClient creates a client backed by the given clientsets. The metrics
+// clientset may be nil, in which case only requested resources are reported.
+func NewClient(clientset kubernetes.Interface, metrics metricsclientset.Interface, cfg *config.Config) *Client {
 	return &Client{
 		clientset: clientset,
+		metrics:   metrics,
 		config:    cfg,
 		namespace: cfg.Namespace,
-	}, nil
+	}
 }
 
-// buildConfig builds the Kubernetes configuration
-func buildConfig(kubeconfig, context string) (*rest.Config, error) {
-	// Try in-cluster config first
-	if config, err := rest.InClusterConfig(); err == nil {
-		return config, nil
+// NewClientForConfig creates a client for the cluster described by restConfig
+func NewClientForConfig(restConfig *rest.Config, cfg *config.Config) (*Client, error) {
+	// Create clientset
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create clientset: %w", err)
 	}
 
-	// Use kubeconfig
-	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
-		&clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfig},
-		&clientcmd.ConfigOverrides{CurrentContext: context},
-	).ClientConfig()
+	// Create metrics clientset; whether metrics-server is installed is checked per request
+	metrics, err := metricsclientset.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metrics clientset: %w", err)
+	}
+
+	client := NewClient(clientset, metrics, cfg)
+	client.credentialPlugin = credentialPlugin(restConfig)
+	return client, nil
 }
 
-// GetClusterStatus returns the overall cluster status
-func (c *Client) GetClusterStatus(ctx context.Context) (*models.ClusterStatus, error) {
-	// Get server version
-	version, err := c.clientset.Discovery().ServerVersion()
+// NewClientFromKubeconfig creates a client for the kubeconfig and context in cfg
+func NewClientFromKubeconfig(cfg *config.Config) (*Client, error) {
+	return NewClientForContext(cfg, cfg.Context)
+}
+
+// NewClientForContext creates a client for the named kubeconfig context, or
+// the kubeconfig's current-context when contextName is empty
+func NewClientForContext(cfg *config.Config, contextName string) (*Client, error) {
+	// Build config from kubeconfig
+	restConfig, resolvedContext, err := buildConfig(cfg, contextName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get server version: %w", err)
+		return nil, fmt.Errorf("failed to build config: %w", err)
 	}
 
-	// Get current context
-	currentContext, err := c.GetCurrentContext()
+	client, err := NewClientForConfig(restConfig, cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get current context: %w", err)
+		return nil, err
 	}
 
-	// Get pod status
-	podStatus, err := c.GetPodStatus(ctx, c.config.Namespace)
+	// Remember which context the client connects to, so a later change of
+	// current-context in the kubeconfig does not misreport the cluster name
+	client.contextName = resolvedContext
+	client.defaultNamespace = contextNamespace(cfg, resolvedContext)
+
+	return client, nil
+}
+
+// GetClusterStatus returns the overall cluster status. Independent resources
+// are fetched concurrently, every API call is bounded by the configured
+// request timeout and the duration of each phase is recorded in Timings.
+func (c *Client) GetClusterStatus(ctx context.Context) (*models.ClusterStatus, error) {
+	timer := newPhaseTimer()
+
+	// Get server version, cached between refreshes
+	var serverVersion *version.Info
+	err := timer.time(phaseVersion, func() (err error) {
+		reqCtx, cancel := c.requestContext(ctx)
+		defer cancel()
+		serverVersion, err = c.cachedServerVersion(reqCtx)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get pod status: %w", err)
+		return nil, c.loginError(fmt.Errorf("failed to get server version: %w", err))
+	}
+
+	// Skip features the user has no access to
+	var perms *models.Permissions
+	_ = timer.time(phasePermissions, func() error {
+		reqCtx, cancel := c.requestContext(ctx)
+		defer cancel()
+		perms = c.ensurePermissions(reqCtx)
+		return nil
+	})
+
+	// Pods are listed once for both the pod status and resource requests
+	withResources := c.config.ShowMetrics && perms.Allowed(models.FeatureResources)
+	withNodes := perms.Allowed(models.FeatureNodes)
+
+	var (
+		wg             sync.WaitGroup
+		currentContext string
+		contextErr     error
+		podStatus      *models.PodStatus
+		usage          *podUsage
+		podErr         error
+		nodes          []*corev1.Node
+		nodesErr       error
+		used           resourceTotals
+		metricsOK      bool
+		workloadStatus *models.WorkloadStatus
+		workloadErr    error
+		jobStatus      *models.JobStatus
+		jobErr         error
+		events         []models.Event
+		eventsErr      error
+	)
+
+	// run fetches one phase in the background with its own request timeout
+	run := func(phase string, fetch func(ctx context.Context) error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			reqCtx, cancel := c.requestContext(ctx)
+			defer cancel()
+			_ = timer.time(phase, func() error { return fetch(reqCtx) })
+		}()
+	}
+
+	run(phaseContext, func(context.Context) error {
+		currentContext, contextErr = c.GetCurrentContext()
+		return contextErr
+	})
+	run(phasePods, func(ctx context.Context) error {
+		podStatus, usage, podErr = c.collectPods(ctx, c.config.Namespace, withResources)
+		return podErr
+	})
+	if withNodes || withResources {
+		run(phaseNodes, func(ctx context.Context) error {
+			nodes, nodesErr = c.listNodes(ctx)
+			return nodesErr
+		})
+	}
+	if withResources {
+		run(phaseMetrics, func(ctx context.Context) error {
+			used, metricsOK = c.getActualUsage(ctx)
+			return nil
+		})
+	}
+	if perms.Allowed(models.FeatureWorkloads) {
+		run(phaseWorkloads, func(ctx context.Context) error {
+			workloadStatus, workloadErr = c.GetWorkloadStatus(ctx, c.config.Namespace)
+			return workloadErr
+		})
+	}
+	if perms.Allowed(models.FeatureJobs) {
+		run(phaseJobs, func(ctx context.Context) error {
+			jobStatus, jobErr = c.GetJobStatus(ctx, c.config.Namespace)
+			return jobErr
+		})
+	}
+	if c.config.ShowEvents && perms.Allowed(models.FeatureEvents) {
+		run(phaseEvents, func(ctx context.Context) error {
+			events, eventsErr = c.GetWarningEvents(ctx, c.config.Namespace)
+			return eventsErr
+		})
+	}
+	wg.Wait()
+
+	if contextErr != nil {
+		return nil, fmt.Errorf("failed to get current context: %w", contextErr)
+	}
+	if podErr != nil {
+		// The cluster may have been replaced or upgraded by the time it is back
+		c.forgetServerVersion()
+		return nil, c.loginError(fmt.Errorf("failed to get pod status: %w", podErr))
+	}
+	health := calculateHealthStatus(podStatus)
+
+	// Get node status; failures are not fatal since pods can still be reported
+	if nodesErr != nil {
+		fmt.Printf("Warning: failed to get node status: %v\n", nodesErr)
+	}
+	var nodeStatus *models.NodeStatus
+	if withNodes && nodesErr == nil {
+		nodeStatus = buildNodeStatus(nodes)
+		if nodeStatus.Total > 0 {
+			health = models.WorstHealth(health, calculateNodeHealth(nodeStatus))
+		}
 	}
 
 	// Get resource statistics if enabled
 	var resourceStats *models.ResourceStats
-	if c.config.ShowMetrics {
-		resourceStats, err = c.GetResourceStats(ctx)
-		if err != nil {
+	if withResources && nodesErr == nil {
+		switch {
+		case usage == nil:
 			// Log error but don't fail - resource stats are optional
-			fmt.Printf("Warning: failed to get resource stats: %v\n", err)
-			resourceStats = nil
+			fmt.Printf("Warning: failed to get resource stats: pods could not be listed in all namespaces\n")
+		case len(nodes) == 0:
+			fmt.Printf("Warning: failed to get resource stats: no nodes found in cluster\n")
+		default:
+			resourceStats = buildResourceStats(nodes, usage, used, metricsOK)
 		}
 	}
 
+	// Get workload rollout status; optional like node status
+	if workloadErr != nil {
+		fmt.Printf("Warning: failed to get workload status: %v\n", workloadErr)
+		workloadStatus = nil
+	} else if workloadStatus != nil {
+		health = models.WorstHealth(health, calculateWorkloadHealth(workloadStatus))
+	}
+
+	// Get Job and CronJob status; optional like workload status
+	if jobErr != nil {
+		fmt.Printf("Warning: failed to get job status: %v\n", jobErr)
+		jobStatus = nil
+	} else if jobStatus != nil {
+		health = models.WorstHealth(health, calculateJobHealth(jobStatus))
+	}
+
+	// Get recent warning events if enabled
+	if eventsErr != nil {
+		// Log error but don't fail - events are optional
+		fmt.Printf("Warning: failed to get events: %v\n", eventsErr)
+		events = nil
+	}
+
 	return &models.ClusterStatus{
 		ClusterName:   currentContext,
-		ServerVersion: version.String(),
+		ServerVersion: serverVersion.String(),
 		PodStatus:     podStatus,
 		Resources:     resourceStats,
+		NodeStatus:    nodeStatus,
+		Workloads:     workloadStatus,
+		Jobs:          jobStatus,
+		Events:        events,
+		Permissions:   perms,
+		Timings:       timer.finish(),
 		LastUpdated:   time.Now(),
-		HealthStatus:  calculateHealthStatus(podStatus),
+		HealthStatus:  health,
 	}, nil
 }
 
-// GetPodStatus returns pod status for the specified namespace
-func (c *Client) GetPodStatus(ctx context.Context, namespace string) (*models.PodStatus, error) {
-	// Determine which namespace to query
-	var queryNamespace string
-	if namespace == config.AllNamespaces {
-		queryNamespace = "" // Empty string means all namespaces
-	} else {
-		queryNamespace = namespace
+// requestContext returns a context bounded by the configured request timeout
+func (c *Client) requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	timeout := c.config.RequestTimeout
+	if timeout <= 0 {
+		timeout = config.DefaultRequestTimeout
 	}
+	return context.WithTimeout(ctx, timeout)
+}
 
-	// List pods in namespace
-	pods, err := c.clientset.CoreV1().Pods(queryNamespace).List(ctx, metav1.ListOptions{
-		ResourceVersion: "0",
-	})
+// serverVersion returns the API server version; unlike
+// Discovery().ServerVersion() the request honors ctx
+func (c *Client) serverVersion(ctx context.Context) (*version.Info, error) {
+	restClient := c.clientset.Discovery().RESTClient()
+	if restClient == nil {
+		// Fake clientsets have no REST client
+		return c.clientset.Discovery().ServerVersion()
+	}
+
+	body, err := restClient.Get().AbsPath("/version").Do(ctx).Raw()
 	if err != nil {
-		return nil, fmt.Errorf("failed to list pods: %w", err)
-	}
-
-	status := &models.PodStatus{
-		Total:           len(pods.Items),
-		Running:         0,
-		RunningReady:    0,
-		RunningNotReady: 0,
-		Pending:         0,
-		Failed:          0,
-		Unknown:         0,
-		Completed:       0,
-		Details:         make([]models.PodDetail, 0, len(pods.Items)),
-	}
-
-	// Process each pod
-	for _, pod := range pods.Items {
-		detail := models.PodDetail{
-			Name:      pod.Name,
-			Namespace: pod.Namespace,
-			Phase:     string(pod.Status.Phase),
-			Ready:     isPodReady(&pod),
-			Restarts:  getRestartCount(&pod),
-			Age:       time.Since(pod.CreationTimestamp.Time),
-		}
+		return nil, err
+	}
 
-		status.Details = append(status.Details, detail)
+	var info version.Info
+	if err := json.Unmarshal(body, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse server version: %w", err)
+	}
+	return &info, nil
+}
+
+// GetPodStatus returns pod status for the specified namespace. Every pod is
+// counted, but only the most relevant pods of each category are kept in Details.
+func (c *Client) GetPodStatus(ctx context.Context, namespace string) (*models.PodStatus, error) {
+	status, _, err := c.collectPods(ctx, namespace, false)
+	return status, err
+}
 
-		// Update counters
-		switch pod.Status.Phase {
-		case corev1.PodRunning:
-			status.Running++
-			if isPodReady(&pod) {
-				status.RunningReady++
-			} else {
-				status.RunningNotReady++
+// collectPods builds the pod status of namespace and, when withUsage is set,
+// sums the requests and limits of all pods in the cluster from the same list.
+// Usage is nil when pods could not be listed in all namespaces.
+func (c *Client) collectPods(ctx context.Context, namespace string, withUsage bool) (*models.PodStatus, *podUsage, error) {
+	status := &models.PodStatus{}
+	details := newPodDetailSet(c.maxPodDetails())
+
+	if withUsage {
+		usage := &podUsage{}
+		err := c.eachPod(ctx, "", func(pod *corev1.Pod) {
+			usage.add(pod)
+			if namespace == config.AllNamespaces || pod.Namespace == namespace {
+				countPod(status, details, pod)
 			}
-		case corev1.PodPending:
-			status.Pending++
-		case corev1.PodSucceeded:
-			status.Completed++
-		case corev1.PodFailed:
-			status.Failed++
-		default:
-			status.Unknown++
+		})
+		if err == nil {
+			status.Details, status.Omitted = details.result()
+			return status, usage, nil
+		}
+		if namespace == config.AllNamespaces || !apierrors.IsForbidden(err) {
+			return nil, nil, fmt.Errorf("failed to list pods: %w", err)
 		}
+
+		// Pods may still be listed in the monitored namespace alone
+		status = &models.PodStatus{}
+		details = newPodDetailSet(c.maxPodDetails())
+	}
+
+	// List pods in the namespace, or the namespaces the user may access
+	err := eachNamespace(c.queryNamespaces(namespace), func(ns string) error {
+		return c.eachPod(ctx, ns, func(pod *corev1.Pod) {
+			countPod(status, details, pod)
+		})
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list pods: %w", err)
 	}
 
-	return status, nil
+	status.Details, status.Omitted = details.result()
+	return status, nil, nil
 }
 
-// GetAllNamespaces returns all namespaces in the cluster
+// countPod adds a pod to the status counters and its details to the set
+func countPod(status *models.PodStatus, details *podDetailSet, pod *corev1.Pod) {
+	reason, issue, issues := classifyPod(pod, time.Now())
+	detail := models.PodDetail{
+		Name:      pod.Name,
+		Namespace: pod.Namespace,
+		Phase:     string(pod.Status.Phase),
+		Ready:     isPodReady(pod),
+		Restarts:  getRestartCount(pod),
+		Age:       time.Since(pod.CreationTimestamp.Time),
+		Reason:    reason,
+		Issue:     issue,
+		Issues:    issues,
+	}
+	if owner := metav1.GetControllerOf(pod); owner != nil {
+		detail.OwnerKind = owner.Kind
+		detail.OwnerName = owner.Name
+	}
+
+	details.add(detail)
+	status.Total++
+
+	if pod.Status.Phase == corev1.PodRunning {
+		status.Running++
+	}
+
+	// Pods with a container issue are counted under that issue instead of their phase
+	switch issue {
+	case models.PodIssueCrashLoop:
+		status.CrashLoop++
+		return
+	case models.PodIssueImagePull:
+		status.ImagePullError++
+		return
+	case models.PodIssueConfigError:
+		status.ConfigError++
+		return
+	case models.PodIssueOOMKilled:
+		status.OOMKilled++
+		return
+	}
+
+	// Update counters
+	switch pod.Status.Phase {
+	case corev1.PodRunning:
+		if detail.Ready {
+			status.RunningReady++
+		} else {
+			status.RunningNotReady++
+		}
+	case corev1.PodPending:
+		status.Pending++
+	case corev1.PodSucceeded:
+		status.Completed++
+	case corev1.PodFailed:
+		status.Failed++
+		if detail.OwnerKind == "Job" {
+			status.FailedJobPods++
+		}
+	default:
+		status.Unknown++
+	}
+}
+
+// GetAllNamespaces returns all namespaces in the cluster, or the fallback
+// namespaces when the user may not list namespaces
 func (c *Client) GetAllNamespaces(ctx context.Context) ([]string, error) {
+	c.mu.RLock()
+	perms := c.permissions
+	c.mu.RUnlock()
+	if !perms.Allowed(models.FeatureNamespaces) {
+		return c.fallbackNamespaces(), nil
+	}
+
+	if cc := c.syncedCache(); cc.serves(models.FeatureNamespaces) {
+		namespaces, err := cc.listNamespaces()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list namespaces: %w", err)
+		}
+
+		names := make([]string, len(namespaces))
+		for i, ns := range namespaces {
+			names[i] = ns.Name
+		}
+		sort.Strings(names)
+
+		return names, nil
+	}
+
 	namespaces, err := c.clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{
 		ResourceVersion: "0",
 	})
@@ -181,165 +473,129 @@ func (c *Client) GetAllNamespaces(ctx context.Context) ([]string, error) {
 	return names, nil
 }
 
-// GetEvents returns recent events in the namespace
-func (c *Client) GetEvents(ctx context.Context, namespace string) ([]models.Event, error) {
-	// Determine which namespace to query
-	var queryNamespace string
-	if namespace == config.AllNamespaces {
-		queryNamespace = "" // Empty string means all namespaces
+// TestConnection tests the connection to the Kubernetes cluster, giving up when ctx is done
+func (c *Client) TestConnection(ctx context.Context) error {
+	var err error
+	if restClient := c.clientset.Discovery().RESTClient(); restClient != nil {
+		err = restClient.Get().AbsPath("/version").Do(ctx).Error()
 	} else {
-		queryNamespace = namespace
+		// Fake clientsets have no REST client
+		_, err = c.clientset.Discovery().ServerVersion()
 	}
-
-	events, err := c.clientset.CoreV1().Events(queryNamespace).List(ctx, metav1.ListOptions{
-		Limit:           50,
-		ResourceVersion: "0",
-	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list events: %w", err)
-	}
-
-	result := make([]models.Event, len(events.Items))
-	for i, event := range events.Items {
-		result[i] = models.Event{
-			Type:      event.Type,
-			Reason:    event.Reason,
-			Message:   event.Message,
-			Object:    event.InvolvedObject.Name,
-			Timestamp: event.LastTimestamp.Time,
-		}
-	}
-
-	return result, nil
-}
-
-// TestConnection tests the connection to the Kubernetes cluster
-func (c *Client) TestConnection(ctx context.Context) error {
-	_, err := c.clientset.Discovery().ServerVersion()
-	if err != nil {
-		return fmt.Errorf("failed to connect to cluster: %w", err)
+		return c.loginError(fmt.Errorf("failed to connect to cluster: %w", err))
 	}
 	return nil
 }
 
-// GetResourceStats returns cluster resource statistics (CPU and Memory)
+// GetResourceStats returns cluster resource statistics (CPU and Memory).
+// Actual usage comes from metrics-server when installed; requests and limits
+// are always summed from running and pending pods.
 func (c *Client) GetResourceStats(ctx context.Context) (*models.ResourceStats, error) {
 	// Get all nodes
-	nodes, err := c.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{
-		ResourceVersion: "0",
-	})
+	nodes, err := c.listNodes(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list nodes: %w", err)
 	}
 
-	if len(nodes.Items) == 0 {
+	if len(nodes) == 0 {
 		return nil, fmt.Errorf("no nodes found in cluster")
 	}
 
-	var totalCPUCores float64
-	var totalMemoryGB float64
+	// Get resource requests and limits from all pods
+	usage := &podUsage{}
+	if err := c.eachPod(ctx, "", usage.add); err != nil {
+		return nil, fmt.Errorf("failed to calculate resource usage: failed to list pods: %w", err)
+	}
 
-	// Calculate total allocatable resources from all nodes
-	for _, node := range nodes.Items {
-		// Get CPU capacity (in millicores)
-		if cpuQuantity, ok := node.Status.Allocatable[corev1.ResourceCPU]; ok {
-			totalCPUCores += float64(cpuQuantity.MilliValue()) / 1000.0
-		}
+	// Get actual usage from metrics-server if available
+	used, metricsAvailable := c.getActualUsage(ctx)
 
-		// Get Memory capacity (in bytes)
-		if memQuantity, ok := node.Status.Allocatable[corev1.ResourceMemory]; ok {
-			totalMemoryGB += float64(memQuantity.Value()) / (1024 * 1024 * 1024)
-		}
+	return buildResourceStats(nodes, usage, used, metricsAvailable), nil
+}
+
+// buildResourceStats compares usage and pod requests and limits with the
+// allocatable resources of all nodes
+func buildResourceStats(nodes []*corev1.Node, usage *podUsage, used resourceTotals, metricsAvailable bool) *models.ResourceStats {
+	// Calculate total allocatable resources from all nodes
+	var allocatable resourceTotals
+	for _, node := range nodes {
+		allocatable.add(node.Status.Allocatable)
 	}
 
-	// Get resource requests from all pods to calculate usage
-	usedCPUCores, usedMemoryGB, err := c.calculateResourceUsage(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to calculate resource usage: %w", err)
+	return &models.ResourceStats{
+		CPU:              newResourceStat(used.cpuCores, usage.requests.cpuCores, usage.limits.cpuCores, allocatable.cpuCores),
+		Memory:           newResourceStat(used.memoryGB, usage.requests.memoryGB, usage.limits.memoryGB, allocatable.memoryGB),
+		MetricsAvailable: metricsAvailable,
 	}
+}
 
-	// Calculate percentages
-	cpuPercentage := 0.0
-	if totalCPUCores > 0 {
-		cpuPercentage = (usedCPUCores / totalCPUCores) * 100
+// newResourceStat builds a ResourceStat with percentages of the available amount
+func newResourceStat(used, requested, limits, available float64) *models.ResourceStat {
+	stat := &models.ResourceStat{
+		Used:      used,
+		Requested: requested,
+		Limits:    limits,
+		Available: available,
 	}
 
-	memoryPercentage := 0.0
-	if totalMemoryGB > 0 {
-		memoryPercentage = (usedMemoryGB / totalMemoryGB) * 100
+	if available > 0 {
+		stat.Percentage = (used / available) * 100
+		stat.RequestedPercentage = (requested / available) * 100
+		stat.LimitsPercentage = (limits / available) * 100
 	}
 
-	return &models.ResourceStats{
-		CPU: &models.ResourceStat{
-			Used:       usedCPUCores,
-			Available:  totalCPUCores,
-			Percentage: cpuPercentage,
-		},
-		Memory: &models.ResourceStat{
-			Used:       usedMemoryGB,
-			Available:  totalMemoryGB,
-			Percentage: memoryPercentage,
-		},
-	}, nil
+	return stat
 }
 
-// calculateResourceUsage calculates the total resource requests from all pods
-func (c *Client) calculateResourceUsage(ctx context.Context) (float64, float64, error) {
-	// Get all pods in all namespaces
-	pods, err := c.clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+// Helper functions
+
+// listNodes returns nodes from the informer cache when synced, otherwise from the API server
+func (c *Client) listNodes(ctx context.Context) ([]*corev1.Node, error) {
+	if cc := c.syncedCache(); cc != nil {
+		return cc.listNodes()
+	}
+
+	nodes, err := c.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{
 		ResourceVersion: "0",
 	})
 	if err != nil {
-		return 0, 0, fmt.Errorf("failed to list pods: %w", err)
+		return nil, err
 	}
 
-	var totalCPUCores float64
-	var totalMemoryGB float64
-
-	for _, pod := range pods.Items {
-		// Skip pods that are not running
-		if pod.Status.Phase != corev1.PodRunning && pod.Status.Phase != corev1.PodPending {
-			continue
-		}
-
-		// Sum up resource requests from all containers
-		for _, container := range pod.Spec.Containers {
-			if requests := container.Resources.Requests; requests != nil {
-				// CPU requests (in millicores)
-				if cpuQuantity, ok := requests[corev1.ResourceCPU]; ok {
-					totalCPUCores += float64(cpuQuantity.MilliValue()) / 1000.0
-				}
-
-				// Memory requests (in bytes)
-				if memQuantity, ok := requests[corev1.ResourceMemory]; ok {
-					totalMemoryGB += float64(memQuantity.Value()) / (1024 * 1024 * 1024)
-				}
-			}
-		}
+	result := make([]*corev1.Node, len(nodes.Items))
+	for i := range nodes.Items {
+		result[i] = &nodes.Items[i]
 	}
-
-	return totalCPUCores, totalMemoryGB, nil
+	return result, nil
 }
 
-// Helper functions
-
 // GetCurrentContext returns the current context name
 func (c *Client) GetCurrentContext() (string, error) {
-	config, err := clientcmd.LoadFromFile(c.config.KubeConfig)
-	if err != nil {
-		return "", err
+	if c.contextName != "" {
+		return c.contextName, nil
 	}
 
+	// An explicitly selected context does not need the kubeconfig to be read
 	if c.config.Context != "" {
 		return c.config.Context, nil
 	}
 
+	config, err := loadKubeconfig(c.config)
+	if err != nil {
+		return "", err
+	}
+
 	return config.CurrentContext, nil
 }
 
 // GetAllContexts returns all available contexts from the kubeconfig
 func (c *Client) GetAllContexts() ([]string, error) {
-	config, err := clientcmd.LoadFromFile(c.config.KubeConfig)
+	if c.contextName == InClusterContext {
+		return []string{InClusterContext}, nil
+	}
+
+	config, err := loadKubeconfig(c.config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
 	}
@@ -348,6 +604,7 @@ func (c *Client) GetAllContexts() ([]string, error) {
 	for contextName := range config.Contexts {
 		contexts = append(contexts, contextName)
 	}
+	sort.Strings(contexts)
 
 	return contexts, nil
 }
@@ -373,10 +630,28 @@ func getRestartCount(pod *corev1.Pod) int32 {
 
 // calculateHealthStatus determines the overall health status
 func calculateHealthStatus(podStatus *models.PodStatus) models.HealthStatus {
-	if podStatus.Failed > 0 {
+	// Failed Job pods are judged by the Job's outcome instead
+	if podStatus.Failed-podStatus.FailedJobPods > 0 {
+		return models.HealthCritical
+	}
+
+	// Container issues contribute according to their severity
+	issueHealth := models.HealthHealthy
+	for issue, count := range map[models.PodIssue]int{
+		models.PodIssueCrashLoop:   podStatus.CrashLoop,
+		models.PodIssueImagePull:   podStatus.ImagePullError,
+		models.PodIssueConfigError: podStatus.ConfigError,
+		models.PodIssueOOMKilled:   podStatus.OOMKilled,
+	} {
+		if count > 0 && issue.Severity() > issueHealth {
+			issueHealth = issue.Severity()
+		}
+	}
+	if issueHealth == models.HealthCritical {
 		return models.HealthCritical
 	}
-	if podStatus.Pending > 0 || podStatus.Unknown > 0 || podStatus.RunningNotReady > 0 {
+
+	if issueHealth == models.HealthWarning || podStatus.Pending > 0 || podStatus.Unknown > 0 || podStatus.RunningNotReady > 0 {
 		return models.HealthWarning
 	}
 	if podStatus.RunningReady > 0 {
@@ -0,0 +1,461 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/mattlqx/k8s-tray/internal/config"
+	"github.com/mattlqx/k8s-tray/pkg/models"
+)
+
+// newTestClient creates a client backed by a fake clientset holding objects,
+// for a user allowed to list everything
+func newTestClient(namespace string, objects ...runtime.Object) *Client {
+	cfg := &config.Config{Namespace: namespace, Context: "test"}
+	clientset := fake.NewSimpleClientset(objects...)
+	reviewAccess(clientset, func(*authorizationv1.ResourceAttributes) bool { return true })
+	clientset.PrependReactor("create", "selfsubjectrulesreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectRulesReview)
+		review.Status.ResourceRules = []authorizationv1.ResourceRule{
+			{Verbs: []string{"*"}, APIGroups: []string{"*"}, Resources: []string{"*"}},
+		}
+		return true, review, nil
+	})
+	return NewClient(clientset, nil, cfg)
+}
+
+// reviewAccess answers the fake clientset's access reviews with allowed
+func reviewAccess(clientset *fake.Clientset, allowed func(*authorizationv1.ResourceAttributes) bool) {
+	clientset.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		review.Status.Allowed = allowed(review.Spec.ResourceAttributes)
+		return true, review, nil
+	})
+}
+
+// newTestPod creates a pod in the given phase with one container
+func newTestPod(namespace, name string, phase corev1.PodPhase, ready bool) *corev1.Pod {
+	readyStatus := corev1.ConditionFalse
+	if ready {
+		readyStatus = corev1.ConditionTrue
+	}
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app"}},
+		},
+		Status: corev1.PodStatus{
+			Phase:      phase,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: readyStatus}},
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "app",
+				Ready: ready,
+			}},
+		},
+	}
+}
+
+// withResources sets the container's requests and limits
+func withResources(pod *corev1.Pod, cpuRequest, memRequest, cpuLimit, memLimit string) *corev1.Pod {
+	pod.Spec.Containers[0].Resources = corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpuRequest),
+			corev1.ResourceMemory: resource.MustParse(memRequest),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpuLimit),
+			corev1.ResourceMemory: resource.MustParse(memLimit),
+		},
+	}
+	return pod
+}
+
+// newTestNode creates a ready node with the given allocatable resources
+func newTestNode(name, cpu, memory string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpu),
+				corev1.ResourceMemory: resource.MustParse(memory),
+			},
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+		},
+	}
+}
+
+func TestGetPodStatus(t *testing.T) {
+	pods := []runtime.Object{
+		newTestPod("default", "ready", corev1.PodRunning, true),
+		newTestPod("default", "not-ready", corev1.PodRunning, false),
+		newTestPod("default", "pending", corev1.PodPending, false),
+		newTestPod("default", "completed", corev1.PodSucceeded, false),
+		newTestPod("default", "failed", corev1.PodFailed, false),
+		newTestPod("kube-system", "dns", corev1.PodRunning, true),
+		newTestPod("kube-system", "proxy", corev1.PodRunning, true),
+	}
+
+	tests := []struct {
+		name      string
+		namespace string
+		expected  models.PodStatus
+	}{
+		{
+			name:      "Single namespace",
+			namespace: "default",
+			expected: models.PodStatus{
+				Total: 5, Running: 2, RunningReady: 1, RunningNotReady: 1, Pending: 1, Completed: 1, Failed: 1,
+			},
+		},
+		{
+			name:      "Other namespace",
+			namespace: "kube-system",
+			expected:  models.PodStatus{Total: 2, Running: 2, RunningReady: 2},
+		},
+		{
+			name:      "All namespaces",
+			namespace: config.AllNamespaces,
+			expected: models.PodStatus{
+				Total: 7, Running: 4, RunningReady: 3, RunningNotReady: 1, Pending: 1, Completed: 1, Failed: 1,
+			},
+		},
+		{
+			name:      "Empty namespace",
+			namespace: "staging",
+			expected:  models.PodStatus{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(tt.namespace, pods...)
+
+			status, err := client.GetPodStatus(context.Background(), tt.namespace)
+			if err != nil {
+				t.Fatalf("Failed to get pod status: %v", err)
+			}
+
+			got := *status
+			got.Details = nil
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected %+v, got %+v", tt.expected, got)
+			}
+			if len(status.Details) != tt.expected.Total {
+				t.Errorf("Expected %d pod details, got %d", tt.expected.Total, len(status.Details))
+			}
+		})
+	}
+}
+
+func TestGetPodStatus_Readiness(t *testing.T) {
+	tests := []struct {
+		name          string
+		pod           *corev1.Pod
+		expectedReady bool
+	}{
+		{
+			name:          "Ready condition true",
+			pod:           newTestPod("default", "web", corev1.PodRunning, true),
+			expectedReady: true,
+		},
+		{
+			name:          "Ready condition false",
+			pod:           newTestPod("default", "web", corev1.PodRunning, false),
+			expectedReady: false,
+		},
+		{
+			name: "No ready condition",
+			pod: func() *corev1.Pod {
+				pod := newTestPod("default", "web", corev1.PodRunning, true)
+				pod.Status.Conditions = nil
+				return pod
+			}(),
+			expectedReady: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient("default", tt.pod)
+
+			status, err := client.GetPodStatus(context.Background(), "default")
+			if err != nil {
+				t.Fatalf("Failed to get pod status: %v", err)
+			}
+
+			if len(status.Details) != 1 {
+				t.Fatalf("Expected 1 pod detail, got %d", len(status.Details))
+			}
+			if status.Details[0].Ready != tt.expectedReady {
+				t.Errorf("Expected ready %t, got %t", tt.expectedReady, status.Details[0].Ready)
+			}
+		})
+	}
+}
+
+func TestGetResourceStats(t *testing.T) {
+	tests := []struct {
+		name              string
+		objects           []runtime.Object
+		expectErr         bool
+		expectedCPU       models.ResourceStat
+		expectedMemoryGB  models.ResourceStat
+		expectedAvailable bool
+	}{
+		{
+			name:      "No nodes",
+			objects:   []runtime.Object{newTestPod("default", "web", corev1.PodRunning, true)},
+			expectErr: true,
+		},
+		{
+			name: "Running and pending pods are summed",
+			objects: []runtime.Object{
+				newTestNode("node-1", "4", "8Gi"),
+				newTestNode("node-2", "4", "8Gi"),
+				withResources(newTestPod("default", "web", corev1.PodRunning, true), "500m", "1Gi", "1", "2Gi"),
+				withResources(newTestPod("kube-system", "dns", corev1.PodPending, false), "1500m", "1Gi", "2", "2Gi"),
+				withResources(newTestPod("default", "done", corev1.PodSucceeded, false), "4", "4Gi", "4", "4Gi"),
+			},
+			expectedCPU: models.ResourceStat{
+				Requested: 2, Limits: 3, Available: 8, RequestedPercentage: 25, LimitsPercentage: 37.5,
+			},
+			expectedMemoryGB: models.ResourceStat{
+				Requested: 2, Limits: 4, Available: 16, RequestedPercentage: 12.5, LimitsPercentage: 25,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient("default", tt.objects...)
+
+			stats, err := client.GetResourceStats(context.Background())
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("Expected an error, got %+v", stats)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to get resource stats: %v", err)
+			}
+
+			if stats.MetricsAvailable != tt.expectedAvailable {
+				t.Errorf("Expected metrics available %t, got %t", tt.expectedAvailable, stats.MetricsAvailable)
+			}
+			assertResourceStat(t, "CPU", tt.expectedCPU, *stats.CPU)
+			assertResourceStat(t, "Memory", tt.expectedMemoryGB, *stats.Memory)
+		})
+	}
+}
+
+// assertResourceStat compares resource stats allowing for floating point rounding
+func assertResourceStat(t *testing.T, label string, expected, got models.ResourceStat) {
+	t.Helper()
+
+	fields := []struct {
+		name          string
+		expected, got float64
+	}{
+		{"Used", expected.Used, got.Used},
+		{"Requested", expected.Requested, got.Requested},
+		{"Limits", expected.Limits, got.Limits},
+		{"Available", expected.Available, got.Available},
+		{"Percentage", expected.Percentage, got.Percentage},
+		{"RequestedPercentage", expected.RequestedPercentage, got.RequestedPercentage},
+		{"LimitsPercentage", expected.LimitsPercentage, got.LimitsPercentage},
+	}
+	for _, f := range fields {
+		if math.Abs(f.expected-f.got) > 0.001 {
+			t.Errorf("%s %s: expected %.3f, got %.3f", label, f.name, f.expected, f.got)
+		}
+	}
+}
+
+func TestGetClusterStatus_Health(t *testing.T) {
+	tests := []struct {
+		name     string
+		objects  []runtime.Object
+		expected models.HealthStatus
+	}{
+		{
+			name:     "No pods",
+			expected: models.HealthUnknown,
+		},
+		{
+			name: "All pods ready",
+			objects: []runtime.Object{
+				newTestNode("node-1", "4", "8Gi"),
+				newTestPod("default", "web", corev1.PodRunning, true),
+				newTestPod("default", "job", corev1.PodSucceeded, false),
+			},
+			expected: models.HealthHealthy,
+		},
+		{
+			name: "Pod not ready",
+			objects: []runtime.Object{
+				newTestNode("node-1", "4", "8Gi"),
+				newTestPod("default", "web", corev1.PodRunning, true),
+				newTestPod("default", "api", corev1.PodRunning, false),
+			},
+			expected: models.HealthWarning,
+		},
+		{
+			name: "Pod failed",
+			objects: []runtime.Object{
+				newTestNode("node-1", "4", "8Gi"),
+				newTestPod("default", "web", corev1.PodRunning, true),
+				newTestPod("default", "api", corev1.PodFailed, false),
+			},
+			expected: models.HealthCritical,
+		},
+		{
+			name: "Failed pod in another namespace",
+			objects: []runtime.Object{
+				newTestNode("node-1", "4", "8Gi"),
+				newTestPod("default", "web", corev1.PodRunning, true),
+				newTestPod("other", "api", corev1.PodFailed, false),
+			},
+			expected: models.HealthHealthy,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient("default", tt.objects...)
+
+			status, err := client.GetClusterStatus(context.Background())
+			if err != nil {
+				t.Fatalf("Failed to get cluster status: %v", err)
+			}
+
+			if status.HealthStatus != tt.expected {
+				t.Errorf("Expected health %s, got %s", tt.expected, status.HealthStatus)
+			}
+			if status.ClusterName != "test" {
+				t.Errorf("Expected cluster name 'test', got '%s'", status.ClusterName)
+			}
+		})
+	}
+}
+
+func TestGetClusterStatus_SharedRequests(t *testing.T) {
+	client := newTestClient("default",
+		newTestNode("node-1", "4", "8Gi"),
+		withResources(newTestPod("default", "web", corev1.PodRunning, true), "500m", "1Gi", "1", "2Gi"),
+		withResources(newTestPod("kube-system", "dns", corev1.PodRunning, true), "1500m", "1Gi", "2", "2Gi"),
+	)
+	client.config.ShowMetrics = true
+	clientset := client.clientset.(*fake.Clientset)
+
+	var status *models.ClusterStatus
+	for i := 0; i < 2; i++ {
+		var err error
+		status, err = client.GetClusterStatus(context.Background())
+		if err != nil {
+			t.Fatalf("Failed to get cluster status: %v", err)
+		}
+	}
+
+	// Pods and nodes are listed once per refresh, the server version once in all
+	counts := make(map[string]int)
+	for _, action := range clientset.Actions() {
+		resource := action.GetResource().Resource
+		counts[action.GetVerb()+" "+resource]++
+		if action.GetVerb() == "list" && resource == "pods" && action.GetNamespace() != "" {
+			t.Errorf("Expected pods to be listed in all namespaces, got namespace '%s'", action.GetNamespace())
+		}
+	}
+	expected := map[string]int{"list pods": 2, "list nodes": 2, "get version": 1}
+	for key, count := range expected {
+		if counts[key] != count {
+			t.Errorf("Expected %d '%s' requests, got %d", count, key, counts[key])
+		}
+	}
+
+	// The pod status covers the namespace, resource requests the whole cluster
+	if status.PodStatus.Total != 1 {
+		t.Errorf("Expected 1 pod in namespace default, got %d", status.PodStatus.Total)
+	}
+	if status.Resources == nil || status.Resources.CPU.Requested != 2 {
+		t.Errorf("Expected 2 cores requested in the cluster, got %+v", status.Resources)
+	}
+
+	for _, phase := range []string{phaseVersion, phasePods, phaseNodes, phaseWorkloads, phaseTotal} {
+		if _, ok := status.Timings[phase]; !ok {
+			t.Errorf("Expected a timing for phase '%s', got %v", phase, status.Timings)
+		}
+	}
+}
+
+func TestGetClusterStatus_LostWhileWatching(t *testing.T) {
+	client := newTestClient("default", newTestPod("default", "web", corev1.PodRunning, true))
+	clientset := client.clientset.(*fake.Clientset)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	updates, err := client.Watch(ctx, time.Millisecond)
+	if err != nil {
+		t.Fatalf("Failed to start watching: %v", err)
+	}
+	select {
+	case <-updates:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the first status")
+	}
+
+	// The informers keep serving their last state after the cluster is lost
+	clientset.PrependReactor("get", "version", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("connection refused")
+	})
+	if _, err := client.GetClusterStatus(context.Background()); err == nil {
+		t.Error("Expected a refresh served from the cache to fail when the cluster is unreachable")
+	}
+}
+
+func TestTestConnection(t *testing.T) {
+	client := newTestClient("default")
+	if err := client.TestConnection(context.Background()); err != nil {
+		t.Errorf("Expected fake cluster to be reachable, got %v", err)
+	}
+}
+
+func TestRequestContext(t *testing.T) {
+	tests := []struct {
+		name     string
+		timeout  time.Duration
+		expected time.Duration
+	}{
+		{name: "Configured", timeout: 3 * time.Second, expected: 3 * time.Second},
+		{name: "Default", timeout: 0, expected: config.DefaultRequestTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient(fake.NewSimpleClientset(), nil, &config.Config{RequestTimeout: tt.timeout})
+			ctx, cancel := client.requestContext(context.Background())
+			defer cancel()
+
+			deadline, ok := ctx.Deadline()
+			if !ok {
+				t.Fatal("Expected request context to have a deadline")
+			}
+			if remaining := time.Until(deadline); remaining > tt.expected || remaining < tt.expected-time.Second {
+				t.Errorf("Expected deadline in %v, got %v", tt.expected, remaining)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package kubernetes
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/mattlqx/k8s-tray/pkg/models"
+)
+
+// crashLoopWindow is how long a container that restarted after a failure
+// counts as crash looping; the kubelet resets the restart backoff of a
+// container that has run for this long
+const crashLoopWindow = 10 * time.Minute
+
+// Waiting reasons that are part of normal container startup
+var benignWaitingReasons = map[string]bool{
+	"ContainerCreating": true,
+	"PodInitializing":   true,
+}
+
+// classifyPod inspects container states and returns the pod's most significant
+// reason, its issue category and every abnormal container state found
+func classifyPod(pod *corev1.Pod, now time.Time) (string, models.PodIssue, []models.ContainerIssue) {
+	var issues []models.ContainerIssue
+	issues = append(issues, containerIssues(pod.Status.InitContainerStatuses, true, now)...)
+	issues = append(issues, containerIssues(pod.Status.ContainerStatuses, false, now)...)
+
+	reason := pod.Status.Reason // e.g. Evicted
+	issue := models.PodIssueNone
+
+	for i := range issues {
+		candidate := issueFor(&issues[i], issues)
+		if issueRank(candidate) > issueRank(issue) {
+			issue = candidate
+			reason = issues[i].Reason
+			if candidate == models.PodIssueOOMKilled {
+				reason = models.ReasonOOMKilled
+			}
+		} else if reason == "" && issues[i].State != models.ContainerStateLastTerminated {
+			reason = issues[i].Reason
+		}
+	}
+
+	// Only pods that are still expected to run are re-categorised by issue
+	if pod.Status.Phase != corev1.PodPending && pod.Status.Phase != corev1.PodRunning {
+		return reason, models.PodIssueNone, issues
+	}
+
+	// Between backoffs a crash-looping container is running or has just failed
+	// again, rather than waiting in CrashLoopBackOff
+	if candidate := restartIssue(pod, now); issueRank(candidate) > issueRank(issue) {
+		issue = candidate
+		reason = models.ReasonCrashLoopBackOff
+		if candidate == models.PodIssueOOMKilled {
+			reason = models.ReasonOOMKilled
+		}
+	}
+
+	return reason, issue, issues
+}
+
+// containerIssues converts abnormal container states into ContainerIssues
+func containerIssues(statuses []corev1.ContainerStatus, init bool, now time.Time) []models.ContainerIssue {
+	var issues []models.ContainerIssue
+
+	for _, cs := range statuses {
+		switch {
+		case cs.State.Waiting != nil && cs.State.Waiting.Reason != "" && !benignWaitingReasons[cs.State.Waiting.Reason]:
+			issues = append(issues, models.ContainerIssue{
+				Container: cs.Name,
+				Init:      init,
+				State:     models.ContainerStateWaiting,
+				Reason:    cs.State.Waiting.Reason,
+				Message:   cs.State.Waiting.Message,
+			})
+		case cs.State.Terminated != nil && cs.State.Terminated.ExitCode != 0:
+			issues = append(issues, models.ContainerIssue{
+				Container: cs.Name,
+				Init:      init,
+				State:     models.ContainerStateTerminated,
+				Reason:    cs.State.Terminated.Reason,
+				Message:   cs.State.Terminated.Message,
+				ExitCode:  cs.State.Terminated.ExitCode,
+			})
+		}
+
+		// Remember OOM kills even after the container restarted, and other
+		// failures while it keeps restarting
+		if last := cs.LastTerminationState.Terminated; last != nil &&
+			(last.Reason == models.ReasonOOMKilled || restartingAfterFailure(cs, now)) {
+			issues = append(issues, models.ContainerIssue{
+				Container: cs.Name,
+				Init:      init,
+				State:     models.ContainerStateLastTerminated,
+				Reason:    last.Reason,
+				Message:   last.Message,
+				ExitCode:  last.ExitCode,
+			})
+		}
+	}
+
+	return issues
+}
+
+// restartingAfterFailure reports whether a container is between crash loop
+// backoffs: it restarted after a failure and has failed again since, or has
+// not run for crashLoopWindow yet
+func restartingAfterFailure(cs corev1.ContainerStatus, now time.Time) bool {
+	last := cs.LastTerminationState.Terminated
+	if cs.RestartCount == 0 || last == nil || last.ExitCode == 0 {
+		return false
+	}
+
+	switch {
+	case cs.State.Terminated != nil:
+		return cs.State.Terminated.ExitCode != 0
+	case cs.State.Running != nil:
+		return now.Sub(cs.State.Running.StartedAt.Time) < crashLoopWindow
+	default:
+		return false
+	}
+}
+
+// restartIssue returns the issue of the pod's containers that are between
+// crash loop backoffs: OOMKilled when one was last OOM killed, CrashLoop otherwise
+func restartIssue(pod *corev1.Pod, now time.Time) models.PodIssue {
+	issue := models.PodIssueNone
+	for _, statuses := range [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+		for _, cs := range statuses {
+			if !restartingAfterFailure(cs, now) {
+				continue
+			}
+			if cs.LastTerminationState.Terminated.Reason == models.ReasonOOMKilled {
+				return models.PodIssueOOMKilled
+			}
+			issue = models.PodIssueCrashLoop
+		}
+	}
+	return issue
+}
+
+// issueFor determines the issue category for a single container state. A
+// container crash-looping because it keeps getting OOM killed is reported as
+// OOMKilled, since that is the actionable cause.
+func issueFor(ci *models.ContainerIssue, all []models.ContainerIssue) models.PodIssue {
+	switch ci.State {
+	case models.ContainerStateLastTerminated:
+		// A past OOM kill only matters while the container is still failing
+		return models.PodIssueNone
+	case models.ContainerStateWaiting:
+		if ci.Reason == models.ReasonCrashLoopBackOff {
+			for _, other := range all {
+				if other.Container == ci.Container && other.Init == ci.Init &&
+					other.State == models.ContainerStateLastTerminated {
+					return models.PodIssueOOMKilled
+				}
+			}
+		}
+	}
+	return models.PodIssueForReason(ci.Reason)
+}
+
+// issueRank orders issues by severity so the most actionable one wins
+func issueRank(issue models.PodIssue) int {
+	switch issue {
+	case models.PodIssueOOMKilled:
+		return 4
+	case models.PodIssueCrashLoop:
+		return 3
+	case models.PodIssueImagePull:
+		return 2
+	case models.PodIssueConfigError:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,110 @@
+package kubernetes
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/mattlqx/k8s-tray/pkg/models"
+)
+
+func TestClassifyPod(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	running := func(ago time.Duration) corev1.ContainerState {
+		return corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(now.Add(-ago))}}
+	}
+	waiting := func(reason string) corev1.ContainerState {
+		return corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: reason}}
+	}
+	terminated := func(reason string, exitCode int32) corev1.ContainerState {
+		return corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: reason, ExitCode: exitCode}}
+	}
+
+	tests := []struct {
+		name           string
+		phase          corev1.PodPhase
+		init           bool // Whether the container is an init container
+		state          corev1.ContainerState
+		last           corev1.ContainerState
+		restarts       int32
+		expectedReason string
+		expectedIssue  models.PodIssue
+	}{
+		{name: "Running", state: running(time.Hour), expectedIssue: models.PodIssueNone},
+		{name: "Creating", phase: corev1.PodPending, state: waiting("ContainerCreating"), expectedIssue: models.PodIssueNone},
+		{
+			name: "Waiting in CrashLoopBackOff", state: waiting(models.ReasonCrashLoopBackOff), last: terminated("Error", 1), restarts: 3,
+			expectedReason: models.ReasonCrashLoopBackOff, expectedIssue: models.PodIssueCrashLoop,
+		},
+		{
+			name: "Running with restarts after a failure", state: running(30 * time.Second), last: terminated("Error", 1), restarts: 4,
+			expectedReason: models.ReasonCrashLoopBackOff, expectedIssue: models.PodIssueCrashLoop,
+		},
+		{
+			name: "Terminated with an error after backoff", state: terminated("Error", 1), last: terminated("Error", 1), restarts: 4,
+			expectedReason: models.ReasonCrashLoopBackOff, expectedIssue: models.PodIssueCrashLoop,
+		},
+		{
+			name: "Running long after the last failure", state: running(time.Hour), last: terminated("Error", 1), restarts: 4,
+			expectedIssue: models.PodIssueNone,
+		},
+		{
+			name: "Running with restarts after exiting cleanly", state: running(30 * time.Second), last: terminated("Completed", 0), restarts: 2,
+			expectedIssue: models.PodIssueNone,
+		},
+		{
+			name: "Terminated with an error before restarting", state: terminated("Error", 1),
+			expectedReason: "Error", expectedIssue: models.PodIssueNone,
+		},
+		{
+			name: "Crash looping after OOM kills", state: waiting(models.ReasonCrashLoopBackOff), last: terminated(models.ReasonOOMKilled, 137), restarts: 2,
+			expectedReason: models.ReasonOOMKilled, expectedIssue: models.PodIssueOOMKilled,
+		},
+		{
+			name: "Running after an OOM kill", state: running(time.Minute), last: terminated(models.ReasonOOMKilled, 137), restarts: 2,
+			expectedReason: models.ReasonOOMKilled, expectedIssue: models.PodIssueOOMKilled,
+		},
+		{
+			name: "Running long after an OOM kill", state: running(time.Hour), last: terminated(models.ReasonOOMKilled, 137), restarts: 1,
+			expectedIssue: models.PodIssueNone,
+		},
+		{
+			name: "Image pull failing", phase: corev1.PodPending, state: waiting(models.ReasonImagePullBackOff),
+			expectedReason: models.ReasonImagePullBackOff, expectedIssue: models.PodIssueImagePull,
+		},
+		{
+			name: "Init container misconfigured", phase: corev1.PodPending, init: true, state: waiting(models.ReasonCreateContainerConfigError),
+			expectedReason: models.ReasonCreateContainerConfigError, expectedIssue: models.PodIssueConfigError,
+		},
+		{
+			name: "Failed pod", phase: corev1.PodFailed, state: terminated("Error", 1), last: terminated("Error", 1), restarts: 2,
+			expectedReason: "Error", expectedIssue: models.PodIssueNone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			phase := tt.phase
+			if phase == "" {
+				phase = corev1.PodRunning
+			}
+			status := corev1.ContainerStatus{Name: "app", State: tt.state, LastTerminationState: tt.last, RestartCount: tt.restarts}
+			pod := &corev1.Pod{Status: corev1.PodStatus{Phase: phase}}
+			if tt.init {
+				pod.Status.InitContainerStatuses = []corev1.ContainerStatus{status}
+			} else {
+				pod.Status.ContainerStatuses = []corev1.ContainerStatus{status}
+			}
+
+			reason, issue, _ := classifyPod(pod, now)
+			if reason != tt.expectedReason {
+				t.Errorf("Expected reason '%s', got '%s'", tt.expectedReason, reason)
+			}
+			if issue != tt.expectedIssue {
+				t.Errorf("Expected '%s', got '%s'", tt.expectedIssue, issue)
+			}
+		})
+	}
+}
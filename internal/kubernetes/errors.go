@@ -0,0 +1,153 @@
+package kubernetes
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/mattlqx/k8s-tray/pkg/models"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/rest"
+)
+
+// LoginRequiredError reports that a context's exec plugin or OIDC credentials
+// were rejected or could not be obtained and need an interactive login
+type LoginRequiredError struct {
+	Plugin string // Credential plugin, e.g. "aws" or "oidc"
+	Err    error
+}
+
+// Error returns the error message
+func (e *LoginRequiredError) Error() string {
+	return fmt.Sprintf("login required for %s credentials: %v", e.Plugin, e.Err)
+}
+
+// Unwrap returns the underlying authentication error
+func (e *LoginRequiredError) Unwrap() error {
+	return e.Err
+}
+
+// credentialPlugin returns the exec plugin or auth provider supplying the
+// configuration's credentials, or "" for static credentials
+func credentialPlugin(restConfig *rest.Config) string {
+	switch {
+	case restConfig.ExecProvider != nil:
+		return filepath.Base(restConfig.ExecProvider.Command)
+	case restConfig.AuthProvider != nil:
+		return restConfig.AuthProvider.Name
+	default:
+		return ""
+	}
+}
+
+// loginError marks authentication failures of plugin-supplied credentials as
+// requiring a login; other errors are returned unchanged
+func (c *Client) loginError(err error) error {
+	if err == nil || c.credentialPlugin == "" {
+		return err
+	}
+
+	switch ClassifyError(err) {
+	case models.ConnectionUnauthorized, models.ConnectionLoginRequired:
+		return &LoginRequiredError{Plugin: c.credentialPlugin, Err: err}
+	default:
+		return err
+	}
+}
+
+// ClassifyError determines why a request to the cluster failed
+func ClassifyError(err error) models.ConnectionState {
+	if err == nil {
+		return models.ConnectionOK
+	}
+
+	var loginErr *LoginRequiredError
+	if errors.As(err, &loginErr) {
+		return models.ConnectionLoginRequired
+	}
+
+	// Errors returned by the API server
+	switch {
+	case apierrors.IsUnauthorized(err):
+		return models.ConnectionUnauthorized
+	case apierrors.IsForbidden(err):
+		return models.ConnectionForbidden
+	case apierrors.IsTimeout(err), apierrors.IsServerTimeout(err):
+		return models.ConnectionTimeout
+	case apierrors.IsInternalError(err), apierrors.IsServiceUnavailable(err), apierrors.IsUnexpectedServerError(err):
+		return models.ConnectionServerError
+	}
+	var status apierrors.APIStatus
+	if errors.As(err, &status) && status.Status().Code >= 500 {
+		return models.ConnectionServerError
+	}
+
+	// Transport errors
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return models.ConnectionDNSError
+	}
+	if isTLSError(err) {
+		return models.ConnectionTLSError
+	}
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return models.ConnectionTimeout
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EHOSTUNREACH) || errors.Is(err, syscall.ENETUNREACH) {
+		return models.ConnectionUnreachable
+	}
+
+	// Some client-go paths, like credential plugins, flatten the error chain
+	return classifyErrorMessage(err.Error())
+}
+
+// isTLSError reports whether the error comes from certificate verification or the TLS handshake
+func isTLSError(err error) bool {
+	var (
+		unknownAuthority x509.UnknownAuthorityError
+		invalidCert      x509.CertificateInvalidError
+		hostnameErr      x509.HostnameError
+		verificationErr  *tls.CertificateVerificationError
+		recordHeaderErr  tls.RecordHeaderError
+	)
+	return errors.As(err, &unknownAuthority) || errors.As(err, &invalidCert) ||
+		errors.As(err, &hostnameErr) || errors.As(err, &verificationErr) ||
+		errors.As(err, &recordHeaderErr)
+}
+
+// classifyErrorMessage classifies errors whose type has been lost by their message
+func classifyErrorMessage(message string) models.ConnectionState {
+	message = strings.ToLower(message)
+	switch {
+	case strings.Contains(message, "getting credentials"), // Exec plugin failed
+		strings.Contains(message, "failed to refresh token"), // OIDC refresh token expired
+		strings.Contains(message, "oidc: "):
+		return models.ConnectionLoginRequired
+	case strings.Contains(message, "unauthorized"):
+		return models.ConnectionUnauthorized
+	case strings.Contains(message, "forbidden"):
+		return models.ConnectionForbidden
+	case strings.Contains(message, "no such host"):
+		return models.ConnectionDNSError
+	case strings.Contains(message, "x509:"), strings.Contains(message, "tls:"):
+		return models.ConnectionTLSError
+	case strings.Contains(message, "timeout"),
+		strings.Contains(message, "deadline exceeded"):
+		return models.ConnectionTimeout
+	case strings.Contains(message, "connection refused"),
+		strings.Contains(message, "connection reset"),
+		strings.Contains(message, "no route to host"),
+		strings.Contains(message, "network is unreachable"):
+		return models.ConnectionUnreachable
+	default:
+		return models.ConnectionFailed
+	}
+}
@@ -0,0 +1,107 @@
+package kubernetes
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/mattlqx/k8s-tray/pkg/models"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestClassifyError(t *testing.T) {
+	pods := schema.GroupResource{Resource: "pods"}
+	urlErr := func(err error) error {
+		return fmt.Errorf("failed to get pod status: %w", &url.Error{Op: "Get", URL: "https://10.0.0.1:6443/api/v1/pods", Err: err})
+	}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected models.ConnectionState
+	}{
+		{name: "No error", err: nil, expected: models.ConnectionOK},
+		{name: "Unauthorized", err: fmt.Errorf("failed to list pods: %w", apierrors.NewUnauthorized("token expired")), expected: models.ConnectionUnauthorized},
+		{name: "Forbidden", err: apierrors.NewForbidden(pods, "", errors.New("no access")), expected: models.ConnectionForbidden},
+		{name: "Server timeout", err: apierrors.NewServerTimeout(pods, "list", 1), expected: models.ConnectionTimeout},
+		{name: "Internal error", err: apierrors.NewInternalError(errors.New("etcd unavailable")), expected: models.ConnectionServerError},
+		{name: "Service unavailable", err: apierrors.NewServiceUnavailable("upgrading"), expected: models.ConnectionServerError},
+		{name: "DNS", err: urlErr(&net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", Name: "api.example.com"}}), expected: models.ConnectionDNSError},
+		{name: "Unknown authority", err: urlErr(x509.UnknownAuthorityError{}), expected: models.ConnectionTLSError},
+		{name: "Expired certificate", err: urlErr(x509.CertificateInvalidError{Reason: x509.Expired}), expected: models.ConnectionTLSError},
+		{name: "Deadline exceeded", err: urlErr(context.DeadlineExceeded), expected: models.ConnectionTimeout},
+		{name: "Dial timeout", err: urlErr(&net.OpError{Op: "dial", Err: os.ErrDeadlineExceeded}), expected: models.ConnectionTimeout},
+		{name: "Connection refused", err: urlErr(&net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}), expected: models.ConnectionUnreachable},
+		{name: "Exec plugin", err: errors.New("getting credentials: exec: executable aws failed with exit code 255"), expected: models.ConnectionLoginRequired},
+		{name: "OIDC refresh", err: errors.New("failed to refresh token: oauth2: cannot fetch token: 400 Bad Request"), expected: models.ConnectionLoginRequired},
+		{name: "Login required", err: &LoginRequiredError{Plugin: "kubelogin", Err: apierrors.NewUnauthorized("expired")}, expected: models.ConnectionLoginRequired},
+		{name: "Flattened TLS error", err: errors.New("Get \"https://10.0.0.1:6443\": x509: certificate has expired or is not yet valid"), expected: models.ConnectionTLSError},
+		{name: "Other", err: errors.New("something went wrong"), expected: models.ConnectionFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := ClassifyError(tt.err); result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestLoginError(t *testing.T) {
+	unauthorized := fmt.Errorf("failed to get server version: %w", apierrors.NewUnauthorized("token expired"))
+	refused := errors.New("dial tcp 10.0.0.1:6443: connect: connection refused")
+
+	tests := []struct {
+		name     string
+		plugin   string
+		err      error
+		expected models.ConnectionState
+	}{
+		{name: "Exec plugin unauthorized", plugin: "aws", err: unauthorized, expected: models.ConnectionLoginRequired},
+		{name: "Static credentials unauthorized", plugin: "", err: unauthorized, expected: models.ConnectionUnauthorized},
+		{name: "Exec plugin unreachable", plugin: "aws", err: refused, expected: models.ConnectionUnreachable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &Client{credentialPlugin: tt.plugin}
+			err := client.loginError(tt.err)
+			if result := ClassifyError(err); result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Error("Expected the original error to be preserved")
+			}
+		})
+	}
+}
+
+func TestCredentialPlugin(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *rest.Config
+		expected string
+	}{
+		{name: "Exec", config: &rest.Config{ExecProvider: &clientcmdapi.ExecConfig{Command: "/usr/local/bin/gke-gcloud-auth-plugin"}}, expected: "gke-gcloud-auth-plugin"},
+		{name: "Auth provider", config: &rest.Config{AuthProvider: &clientcmdapi.AuthProviderConfig{Name: "oidc"}}, expected: "oidc"},
+		{name: "Static token", config: &rest.Config{BearerToken: "token"}, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := credentialPlugin(tt.config); result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
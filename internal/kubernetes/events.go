@@ -0,0 +1,153 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+
+	"github.com/mattlqx/k8s-tray/pkg/models"
+)
+
+// Event counts kept per refresh
+const (
+	maxEvents        = 50
+	maxWarningEvents = 500
+)
+
+// GetEvents returns the most recent events in the namespace, newest first
+func (c *Client) GetEvents(ctx context.Context, namespace string) ([]models.Event, error) {
+	return c.listEvents(ctx, namespace, "", maxEvents)
+}
+
+// GetWarningEvents returns the most recent Warning events in the namespace,
+// deduplicated by involved object and reason, newest first
+func (c *Client) GetWarningEvents(ctx context.Context, namespace string) ([]models.Event, error) {
+	events, err := c.listEvents(ctx, namespace, fields.OneTermEqualSelector("type", corev1.EventTypeWarning).String(), maxWarningEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	return dedupeEvents(events), nil
+}
+
+// listEvents lists the events matching fieldSelector and returns the newest
+// limit of them as models.Event. The API server returns events in name order,
+// so all events are listed a page at a time before keeping the newest.
+func (c *Client) listEvents(ctx context.Context, namespace, fieldSelector string, limit int) ([]models.Event, error) {
+	// List events in the namespace, or the namespaces the user may access
+	result, err := listInNamespaces(c.queryNamespaces(namespace), func(ns string) ([]models.Event, error) {
+		var converted []models.Event
+
+		// No ResourceVersion: the API server's watch cache ignores Limit for "0"
+		opts := metav1.ListOptions{FieldSelector: fieldSelector, Limit: listPageSize}
+		for {
+			page, err := c.clientset.CoreV1().Events(ns).List(ctx, opts)
+			if err != nil {
+				return nil, err
+			}
+			for i := range page.Items {
+				converted = append(converted, convertEvent(&page.Items[i]))
+			}
+
+			if page.Continue == "" {
+				return converted, nil
+			}
+			opts.Continue = page.Continue
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	return newestEvents(result, limit), nil
+}
+
+// newestEvents sorts events newest first and keeps the first limit of them
+func newestEvents(events []models.Event, limit int) []models.Event {
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp.After(events[j].Timestamp)
+	})
+	if len(events) > limit {
+		events = events[:limit]
+	}
+	return events
+}
+
+// convertEvent converts a core/v1 Event, taking the timestamp and count from
+// EventTime/Series for events recorded through the events.k8s.io API
+func convertEvent(event *corev1.Event) models.Event {
+	count := event.Count
+	if event.Series != nil {
+		count = event.Series.Count
+	}
+	if count < 1 {
+		count = 1
+	}
+
+	return models.Event{
+		Type:      event.Type,
+		Reason:    event.Reason,
+		Message:   event.Message,
+		Object:    event.InvolvedObject.Name,
+		Kind:      event.InvolvedObject.Kind,
+		Namespace: event.InvolvedObject.Namespace,
+		Count:     count,
+		Timestamp: eventTimestamp(event),
+	}
+}
+
+// eventTimestamp returns when the event was last observed
+func eventTimestamp(event *corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case event.Series != nil && !event.Series.LastObservedTime.IsZero():
+		return event.Series.LastObservedTime.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	case !event.FirstTimestamp.IsZero():
+		return event.FirstTimestamp.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
+
+// dedupeEvents merges events for the same involved object and reason, summing
+// their counts and keeping the newest message, and sorts them newest first
+func dedupeEvents(events []models.Event) []models.Event {
+	merged := make(map[string]*models.Event)
+	order := make([]string, 0, len(events))
+
+	for _, event := range events {
+		key := fmt.Sprintf("%s/%s/%s/%s", event.Namespace, event.Kind, event.Object, event.Reason)
+		existing, ok := merged[key]
+		if !ok {
+			e := event
+			merged[key] = &e
+			order = append(order, key)
+			continue
+		}
+
+		existing.Count += event.Count
+		if event.Timestamp.After(existing.Timestamp) {
+			existing.Timestamp = event.Timestamp
+			existing.Message = event.Message
+		}
+	}
+
+	result := make([]models.Event, 0, len(order))
+	for _, key := range order {
+		result = append(result, *merged[key])
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Timestamp.After(result[j].Timestamp)
+	})
+
+	return result
+}
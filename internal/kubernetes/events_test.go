@@ -0,0 +1,127 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/mattlqx/k8s-tray/pkg/models"
+)
+
+func TestEventTimestamp(t *testing.T) {
+	last := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	observed := last.Add(time.Minute)
+	eventTime := last.Add(2 * time.Minute)
+
+	tests := []struct {
+		name     string
+		event    corev1.Event
+		expected time.Time
+	}{
+		{
+			name:     "LastTimestamp",
+			event:    corev1.Event{LastTimestamp: metav1.NewTime(last), EventTime: metav1.NewMicroTime(eventTime)},
+			expected: last,
+		},
+		{
+			name: "Series",
+			event: corev1.Event{
+				EventTime: metav1.NewMicroTime(eventTime),
+				Series:    &corev1.EventSeries{Count: 4, LastObservedTime: metav1.NewMicroTime(observed)},
+			},
+			expected: observed,
+		},
+		{
+			name:     "EventTime",
+			event:    corev1.Event{EventTime: metav1.NewMicroTime(eventTime)},
+			expected: eventTime,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := eventTimestamp(&tt.event)
+			if !result.Equal(tt.expected) {
+				t.Errorf("Expected %s, got %s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestConvertEvent_SeriesCount(t *testing.T) {
+	event := convertEvent(&corev1.Event{
+		Type:   corev1.EventTypeWarning,
+		Reason: "BackOff",
+		Series: &corev1.EventSeries{Count: 7},
+	})
+	if event.Count != 7 {
+		t.Errorf("Expected count 7, got %d", event.Count)
+	}
+
+	event = convertEvent(&corev1.Event{Type: corev1.EventTypeWarning, Reason: "Failed"})
+	if event.Count != 1 {
+		t.Errorf("Expected count 1, got %d", event.Count)
+	}
+}
+
+func TestDedupeEvents(t *testing.T) {
+	now := time.Now()
+	events := []models.Event{
+		{Reason: "BackOff", Kind: "Pod", Object: "api", Namespace: "prod", Count: 3, Message: "old", Timestamp: now.Add(-10 * time.Minute)},
+		{Reason: "FailedMount", Kind: "Pod", Object: "db", Namespace: "prod", Count: 1, Timestamp: now.Add(-5 * time.Minute)},
+		{Reason: "BackOff", Kind: "Pod", Object: "api", Namespace: "prod", Count: 2, Message: "new", Timestamp: now.Add(-1 * time.Minute)},
+		{Reason: "BackOff", Kind: "Pod", Object: "api", Namespace: "staging", Count: 1, Timestamp: now.Add(-20 * time.Minute)},
+	}
+
+	result := dedupeEvents(events)
+	if len(result) != 3 {
+		t.Fatalf("Expected 3 events, got %d", len(result))
+	}
+
+	first := result[0]
+	if first.Object != "api" || first.Namespace != "prod" {
+		t.Errorf("Expected newest event for prod/api first, got %s/%s", first.Namespace, first.Object)
+	}
+	if first.Count != 5 {
+		t.Errorf("Expected merged count 5, got %d", first.Count)
+	}
+	if first.Message != "new" {
+		t.Errorf("Expected newest message 'new', got '%s'", first.Message)
+	}
+	if result[1].Object != "db" || result[2].Namespace != "staging" {
+		t.Errorf("Expected events sorted newest first, got %+v", result)
+	}
+}
+
+func TestGetEvents_Newest(t *testing.T) {
+	now := time.Now()
+
+	// Events are listed in name order, here oldest first
+	var objects []runtime.Object
+	for i := 0; i < maxEvents+10; i++ {
+		objects = append(objects, &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: fmt.Sprintf("event-%03d", i), Namespace: "default"},
+			Type:           corev1.EventTypeWarning,
+			Reason:         "BackOff",
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: fmt.Sprintf("pod-%03d", i), Namespace: "default"},
+			LastTimestamp:  metav1.NewTime(now.Add(time.Duration(i) * time.Minute)),
+		})
+	}
+	client := newTestClient("default", objects...)
+
+	events, err := client.GetEvents(context.Background(), "default")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(events) != maxEvents {
+		t.Fatalf("Expected %d events, got %d", maxEvents, len(events))
+	}
+	if first, last := events[0].Object, events[len(events)-1].Object; first != "pod-059" || last != "pod-010" {
+		t.Errorf("Expected the newest events from pod-059 to pod-010, got %s to %s", first, last)
+	}
+}
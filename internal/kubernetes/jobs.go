@@ -0,0 +1,259 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/mattlqx/k8s-tray/pkg/models"
+)
+
+// missedScheduleGrace is how late a CronJob run may start before it counts as
+// missed, when the CronJob does not set startingDeadlineSeconds
+const missedScheduleGrace = 5 * time.Minute
+
+// failedJobMaxAge is how long a failed standalone Job affects health after it
+// finished; like older CronJob runs, it is only listed afterwards
+const failedJobMaxAge = 24 * time.Hour
+
+// GetJobStatus evaluates Jobs and CronJobs in the specified namespace
+func (c *Client) GetJobStatus(ctx context.Context, namespace string) (*models.JobStatus, error) {
+	namespaces := c.queryNamespaces(namespace)
+
+	jobs, err := listInNamespaces(namespaces, func(ns string) ([]*batchv1.Job, error) {
+		return c.listJobs(ctx, ns)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	cronJobs, err := listInNamespaces(namespaces, func(ns string) ([]*batchv1.CronJob, error) {
+		return c.listCronJobs(ctx, ns)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cron jobs: %w", err)
+	}
+
+	return buildJobStatus(jobs, cronJobs, time.Now()), nil
+}
+
+// buildJobStatus groups Jobs under their owning CronJobs and evaluates each
+func buildJobStatus(jobs []*batchv1.Job, cronJobs []*batchv1.CronJob, now time.Time) *models.JobStatus {
+	status := &models.JobStatus{}
+
+	// Index jobs by owning CronJob
+	runs := make(map[string][]models.JobDetail)
+	for _, job := range jobs {
+		detail := evaluateJob(job)
+
+		if detail.CronJob == "" {
+			status.Jobs = append(status.Jobs, detail)
+			switch detail.State {
+			case models.JobRunning:
+				status.Active++
+			case models.JobFailed:
+				if now.Sub(detail.CompletionTime) < failedJobMaxAge {
+					status.Failed++
+				}
+			}
+			continue
+		}
+
+		key := detail.Namespace + "/" + detail.CronJob
+		runs[key] = append(runs[key], detail)
+	}
+
+	for _, cronJob := range cronJobs {
+		detail := evaluateCronJob(cronJob, runs[cronJob.Namespace+"/"+cronJob.Name], now)
+		status.CronJobs = append(status.CronJobs, detail)
+
+		status.Active += detail.Active
+		if detail.Suspended {
+			status.Suspended++
+		}
+		if detail.MissedSchedule {
+			status.MissedSchedules++
+		}
+		if detail.LastRun != nil && detail.LastRun.State == models.JobFailed {
+			status.FailedCronJobs++
+		}
+	}
+
+	sort.Slice(status.CronJobs, func(i, j int) bool {
+		a, b := status.CronJobs[i], status.CronJobs[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	sort.Slice(status.Jobs, func(i, j int) bool {
+		return status.Jobs[i].StartTime.After(status.Jobs[j].StartTime)
+	})
+
+	return status
+}
+
+// evaluateJob determines the outcome of a Job from its conditions
+func evaluateJob(job *batchv1.Job) models.JobDetail {
+	detail := models.JobDetail{
+		Name:      job.Name,
+		Namespace: job.Namespace,
+		Active:    job.Status.Active,
+		Succeeded: job.Status.Succeeded,
+		Failed:    job.Status.Failed,
+		State:     models.JobRunning,
+		StartTime: job.CreationTimestamp.Time,
+	}
+
+	if job.Spec.BackoffLimit != nil {
+		detail.BackoffLimit = *job.Spec.BackoffLimit
+	}
+	if job.Status.StartTime != nil {
+		detail.StartTime = job.Status.StartTime.Time
+	}
+	if job.Status.CompletionTime != nil {
+		detail.CompletionTime = job.Status.CompletionTime.Time
+	}
+	if owner := metav1.GetControllerOf(job); owner != nil && owner.Kind == "CronJob" {
+		detail.CronJob = owner.Name
+	}
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			detail.State = models.JobSucceeded
+		case batchv1.JobFailed:
+			// e.g. BackoffLimitExceeded or DeadlineExceeded
+			detail.State = models.JobFailed
+			detail.Reason = condition.Reason
+			if detail.CompletionTime.IsZero() {
+				detail.CompletionTime = condition.LastTransitionTime.Time
+			}
+		}
+	}
+
+	return detail
+}
+
+// evaluateCronJob summarises a CronJob, its most recent run and whether its schedule was missed
+func evaluateCronJob(cronJob *batchv1.CronJob, runs []models.JobDetail, now time.Time) models.CronJobDetail {
+	detail := models.CronJobDetail{
+		Name:      cronJob.Name,
+		Namespace: cronJob.Namespace,
+		Schedule:  cronJob.Spec.Schedule,
+		Suspended: cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend,
+		Active:    len(cronJob.Status.Active),
+	}
+
+	if cronJob.Status.LastScheduleTime != nil {
+		detail.LastScheduleTime = cronJob.Status.LastScheduleTime.Time
+	}
+	if cronJob.Status.LastSuccessfulTime != nil {
+		detail.LastSuccessfulTime = cronJob.Status.LastSuccessfulTime.Time
+	}
+
+	// Only the most recent run determines the CronJob's outcome
+	for i := range runs {
+		if detail.LastRun == nil || runs[i].StartTime.After(detail.LastRun.StartTime) {
+			detail.LastRun = &runs[i]
+		}
+	}
+
+	if !detail.Suspended {
+		detail.MissedSchedule = missedSchedule(cronJob, now)
+	}
+
+	return detail
+}
+
+// missedSchedule reports whether a CronJob should have started a run by now but did not
+func missedSchedule(cronJob *batchv1.CronJob, now time.Time) bool {
+	// Runs are skipped on purpose while the previous one is still active
+	if cronJob.Spec.ConcurrencyPolicy == batchv1.ForbidConcurrent && len(cronJob.Status.Active) > 0 {
+		return false
+	}
+
+	spec := cronJob.Spec.Schedule
+	if cronJob.Spec.TimeZone != nil && *cronJob.Spec.TimeZone != "" {
+		spec = fmt.Sprintf("CRON_TZ=%s %s", *cronJob.Spec.TimeZone, spec)
+	}
+
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return false
+	}
+
+	last := cronJob.CreationTimestamp.Time
+	if cronJob.Status.LastScheduleTime != nil {
+		last = cronJob.Status.LastScheduleTime.Time
+	}
+
+	grace := missedScheduleGrace
+	if cronJob.Spec.StartingDeadlineSeconds != nil {
+		grace = time.Duration(*cronJob.Spec.StartingDeadlineSeconds) * time.Second
+	}
+
+	next := schedule.Next(last)
+	return !next.IsZero() && now.After(next.Add(grace))
+}
+
+// calculateJobHealth determines the health contribution of Jobs and CronJobs.
+// A failed latest CronJob run is critical; recently failed standalone Jobs and
+// missed schedules are warnings. Older failed runs are ignored.
+func calculateJobHealth(jobStatus *models.JobStatus) models.HealthStatus {
+	if jobStatus.FailedCronJobs > 0 {
+		return models.HealthCritical
+	}
+	if jobStatus.Failed > 0 || jobStatus.MissedSchedules > 0 {
+		return models.HealthWarning
+	}
+	return models.HealthHealthy
+}
+
+// listJobs returns jobs from the informer cache when synced, otherwise from the API server
+func (c *Client) listJobs(ctx context.Context, namespace string) ([]*batchv1.Job, error) {
+	if cc := c.syncedCache(); cc.serves(models.FeatureJobs) {
+		return cc.listJobs(namespace)
+	}
+
+	list, err := c.clientset.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{
+		ResourceVersion: "0",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*batchv1.Job, len(list.Items))
+	for i := range list.Items {
+		result[i] = &list.Items[i]
+	}
+	return result, nil
+}
+
+// listCronJobs returns cron jobs from the informer cache when synced, otherwise from the API server
+func (c *Client) listCronJobs(ctx context.Context, namespace string) ([]*batchv1.CronJob, error) {
+	if cc := c.syncedCache(); cc.serves(models.FeatureJobs) {
+		return cc.listCronJobs(namespace)
+	}
+
+	list, err := c.clientset.BatchV1().CronJobs(namespace).List(ctx, metav1.ListOptions{
+		ResourceVersion: "0",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*batchv1.CronJob, len(list.Items))
+	for i := range list.Items {
+		result[i] = &list.Items[i]
+	}
+	return result, nil
+}
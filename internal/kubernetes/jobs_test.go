@@ -0,0 +1,178 @@
+package kubernetes
+
+import (
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/mattlqx/k8s-tray/pkg/models"
+)
+
+// testJob creates a Job started at start, owned by cronJob when set, with a
+// true condition of the given type unless it is empty
+func testJob(name, cronJob string, start time.Time, condition batchv1.JobConditionType) *batchv1.Job {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", CreationTimestamp: metav1.NewTime(start)},
+		Status:     batchv1.JobStatus{StartTime: &metav1.Time{Time: start}},
+	}
+	if cronJob != "" {
+		controller := true
+		job.OwnerReferences = []metav1.OwnerReference{{Kind: "CronJob", Name: cronJob, Controller: &controller}}
+	}
+	if condition != "" {
+		finished := metav1.NewTime(start.Add(time.Minute))
+		job.Status.Conditions = []batchv1.JobCondition{
+			{Type: condition, Status: corev1.ConditionTrue, Reason: "BackoffLimitExceeded", LastTransitionTime: finished},
+		}
+		if condition == batchv1.JobComplete {
+			job.Status.CompletionTime = &finished
+		}
+	}
+	return job
+}
+
+func TestEvaluateJob(t *testing.T) {
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		job             *batchv1.Job
+		expected        models.JobState
+		expectedReason  string
+		expectedCronJob string
+	}{
+		{name: "Running", job: testJob("migrate", "", start, ""), expected: models.JobRunning},
+		{name: "Succeeded", job: testJob("migrate", "", start, batchv1.JobComplete), expected: models.JobSucceeded},
+		{name: "Failed", job: testJob("migrate", "", start, batchv1.JobFailed), expected: models.JobFailed, expectedReason: "BackoffLimitExceeded"},
+		{name: "CronJob run", job: testJob("backup-28560", "backup", start, ""), expected: models.JobRunning, expectedCronJob: "backup"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detail := evaluateJob(tt.job)
+			if detail.State != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, detail.State)
+			}
+			if detail.Reason != tt.expectedReason {
+				t.Errorf("Expected '%s', got '%s'", tt.expectedReason, detail.Reason)
+			}
+			if detail.CronJob != tt.expectedCronJob {
+				t.Errorf("Expected '%s', got '%s'", tt.expectedCronJob, detail.CronJob)
+			}
+			if detail.State != models.JobRunning && !detail.CompletionTime.Equal(start.Add(time.Minute)) {
+				t.Errorf("Expected completion at %s, got %s", start.Add(time.Minute), detail.CompletionTime)
+			}
+		})
+	}
+}
+
+func TestMissedSchedule(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	deadline := int64(3600)
+
+	tests := []struct {
+		name         string
+		schedule     string
+		lastSchedule time.Time
+		deadline     *int64
+		forbid       bool
+		active       int
+		expected     bool
+	}{
+		{name: "On time", schedule: "0 * * * *", lastSchedule: now.Add(-30 * time.Minute), expected: false},
+		{name: "Within grace", schedule: "28 * * * *", lastSchedule: now.Add(-62 * time.Minute), expected: false},
+		{name: "Missed", schedule: "*/10 * * * *", lastSchedule: now.Add(-time.Hour), expected: true},
+		{name: "Within starting deadline", schedule: "*/10 * * * *", lastSchedule: now.Add(-time.Hour), deadline: &deadline, expected: false},
+		{name: "Previous run still active", schedule: "*/10 * * * *", lastSchedule: now.Add(-time.Hour), active: 1, expected: true},
+		{name: "Forbidden while active", schedule: "*/10 * * * *", lastSchedule: now.Add(-time.Hour), forbid: true, active: 1, expected: false},
+		{name: "Forbidden and idle", schedule: "*/10 * * * *", lastSchedule: now.Add(-time.Hour), forbid: true, expected: true},
+		{name: "Invalid schedule", schedule: "not a schedule", lastSchedule: now.Add(-time.Hour), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cronJob := &batchv1.CronJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "backup", CreationTimestamp: metav1.NewTime(now.Add(-24 * time.Hour))},
+				Spec:       batchv1.CronJobSpec{Schedule: tt.schedule, StartingDeadlineSeconds: tt.deadline},
+				Status:     batchv1.CronJobStatus{LastScheduleTime: &metav1.Time{Time: tt.lastSchedule}},
+			}
+			if tt.forbid {
+				cronJob.Spec.ConcurrencyPolicy = batchv1.ForbidConcurrent
+			}
+			for i := 0; i < tt.active; i++ {
+				cronJob.Status.Active = append(cronJob.Status.Active, corev1.ObjectReference{Name: "backup-1"})
+			}
+
+			if result := missedSchedule(cronJob, now); result != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestBuildJobStatus(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	suspended := true
+	cronJob := func(name string) *batchv1.CronJob {
+		return &batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", CreationTimestamp: metav1.NewTime(now.Add(-time.Hour))},
+			Spec:       batchv1.CronJobSpec{Schedule: "0 * * * *"},
+			Status:     batchv1.CronJobStatus{LastScheduleTime: &metav1.Time{Time: now.Add(-30 * time.Minute)}},
+		}
+	}
+	paused := cronJob("archive")
+	paused.Spec.Suspend = &suspended
+
+	status := buildJobStatus([]*batchv1.Job{
+		testJob("migrate", "", now.Add(-10*time.Minute), ""),
+		testJob("seed", "", now.Add(-2*time.Hour), batchv1.JobFailed),
+		testJob("import", "", now.Add(-48*time.Hour), batchv1.JobFailed),
+		// The older run of report failed, its latest run succeeded
+		testJob("report-1", "report", now.Add(-90*time.Minute), batchv1.JobFailed),
+		testJob("report-2", "report", now.Add(-30*time.Minute), batchv1.JobComplete),
+		testJob("backup-2", "backup", now.Add(-30*time.Minute), batchv1.JobFailed),
+	}, []*batchv1.CronJob{cronJob("report"), cronJob("backup"), paused}, now)
+
+	if status.Active != 1 || status.Failed != 1 || status.FailedCronJobs != 1 || status.Suspended != 1 || status.MissedSchedules != 0 {
+		t.Errorf("Unexpected counts %+v", status)
+	}
+	if len(status.Jobs) != 3 || status.Jobs[0].Name != "migrate" {
+		t.Errorf("Expected the standalone jobs newest first, got %+v", status.Jobs)
+	}
+
+	var names []string
+	for _, detail := range status.CronJobs {
+		names = append(names, detail.Name)
+	}
+	if len(names) != 3 || names[0] != "archive" || names[1] != "backup" || names[2] != "report" {
+		t.Errorf("Expected cron jobs sorted by name, got %v", names)
+	}
+	if run := status.CronJobs[2].LastRun; run == nil || run.Name != "report-2" {
+		t.Errorf("Expected report-2 as the last run, got %+v", run)
+	}
+}
+
+func TestCalculateJobHealth(t *testing.T) {
+	tests := []struct {
+		name      string
+		jobStatus *models.JobStatus
+		expected  models.HealthStatus
+	}{
+		{name: "No jobs", jobStatus: &models.JobStatus{}, expected: models.HealthHealthy},
+		{name: "Active and suspended", jobStatus: &models.JobStatus{Active: 2, Suspended: 1}, expected: models.HealthHealthy},
+		{name: "Failed job", jobStatus: &models.JobStatus{Failed: 1}, expected: models.HealthWarning},
+		{name: "Missed schedule", jobStatus: &models.JobStatus{MissedSchedules: 1}, expected: models.HealthWarning},
+		{name: "Failed cron job", jobStatus: &models.JobStatus{Failed: 1, FailedCronJobs: 1}, expected: models.HealthCritical},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := calculateJobHealth(tt.jobStatus); result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
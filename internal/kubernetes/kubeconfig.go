@@ -0,0 +1,213 @@
+package kubernetes
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/mattlqx/k8s-tray/internal/config"
+	"github.com/mattlqx/k8s-tray/pkg/models"
+)
+
+// loadingRules returns loading rules that merge all configured kubeconfig files
+// the way kubectl merges $KUBECONFIG: the first file to set a value wins
+func loadingRules(cfg *config.Config) *clientcmd.ClientConfigLoadingRules {
+	return &clientcmd.ClientConfigLoadingRules{Precedence: cfg.KubeConfigPaths()}
+}
+
+// errNoKubeconfig is returned by loadKubeconfig when no kubeconfig files are configured
+var errNoKubeconfig = errors.New("no kubeconfig files configured")
+
+// InClusterContext is the context name used for the in-cluster service account
+const InClusterContext = "in-cluster"
+
+// inClusterSource describes where in-cluster credentials come from
+const inClusterSource = "service account"
+
+// buildConfig builds the Kubernetes configuration for the named context, or the
+// kubeconfig's current-context when contextName is empty, according to the
+// connection mode. It returns the name of the context actually used, with
+// InClusterContext standing for the pod's service account.
+func buildConfig(cfg *config.Config, contextName string) (*rest.Config, string, error) {
+	restConfig, resolved, err := baseConfig(cfg, contextName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := applyContextSettings(restConfig, cfg.ContextSettings(resolved)); err != nil {
+		return nil, "", fmt.Errorf("invalid settings for context %s: %w", resolved, err)
+	}
+
+	return restConfig, resolved, nil
+}
+
+// baseConfig builds the configuration from the kubeconfig or the in-cluster service account
+func baseConfig(cfg *config.Config, contextName string) (*rest.Config, string, error) {
+	useInCluster := contextName == InClusterContext
+	switch cfg.ConnectionMode {
+	case config.ConnectionModeInCluster:
+		useInCluster = true
+	case config.ConnectionModeKubeconfig:
+		if useInCluster {
+			return nil, "", fmt.Errorf("context %s is not available in kubeconfig connection mode", InClusterContext)
+		}
+	default:
+		// Only fall back to the service account when there is no kubeconfig or
+		// it defines no contexts; a broken kubeconfig is reported instead
+		if contextName == "" {
+			kubeconfig, err := loadKubeconfig(cfg)
+			switch {
+			case errors.Is(err, errNoKubeconfig):
+				useInCluster = true
+			case err != nil:
+				return nil, "", fmt.Errorf("failed to load kubeconfig: %w", err)
+			default:
+				useInCluster = len(kubeconfig.Contexts) == 0
+			}
+		}
+	}
+
+	if useInCluster {
+		restConfig, err := rest.InClusterConfig()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to load in-cluster config: %w", err)
+		}
+		return restConfig, InClusterContext, nil
+	}
+
+	// Use the merged kubeconfig files
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		loadingRules(cfg),
+		&clientcmd.ConfigOverrides{CurrentContext: contextName},
+	)
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, "", err
+	}
+
+	if contextName == "" {
+		if raw, err := clientConfig.RawConfig(); err == nil {
+			contextName = raw.CurrentContext
+		}
+	}
+
+	return restConfig, contextName, nil
+}
+
+// contextNamespace returns the default namespace of a kubeconfig context
+func contextNamespace(cfg *config.Config, contextName string) string {
+	kubeconfig, err := loadKubeconfig(cfg)
+	if err != nil {
+		return ""
+	}
+	if kubeContext, ok := kubeconfig.Contexts[contextName]; ok {
+		return kubeContext.Namespace
+	}
+	return ""
+}
+
+// applyContextSettings applies per-context overrides to a client configuration
+func applyContextSettings(restConfig *rest.Config, settings config.ContextSettings) error {
+	if settings.Timeout > 0 {
+		restConfig.Timeout = settings.Timeout
+	}
+	if settings.QPS > 0 {
+		restConfig.QPS = settings.QPS
+	}
+	if settings.Burst > 0 {
+		restConfig.Burst = settings.Burst
+	}
+	if settings.ImpersonateUser != "" || len(settings.ImpersonateGroups) > 0 {
+		restConfig.Impersonate = rest.ImpersonationConfig{
+			UserName: settings.ImpersonateUser,
+			Groups:   settings.ImpersonateGroups,
+		}
+	}
+	if settings.ProxyURL != "" {
+		proxyURL, err := url.Parse(settings.ProxyURL)
+		if err != nil {
+			return fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		if proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return fmt.Errorf("invalid proxy URL %q: scheme and host are required", settings.ProxyURL)
+		}
+		restConfig.Proxy = http.ProxyURL(proxyURL)
+	}
+	return nil
+}
+
+// loadKubeconfig loads and merges all configured kubeconfig files
+func loadKubeconfig(cfg *config.Config) (*clientcmdapi.Config, error) {
+	paths := cfg.KubeConfigPaths()
+	if len(paths) == 0 {
+		return nil, errNoKubeconfig
+	}
+
+	return loadingRules(cfg).Load()
+}
+
+// GetContextSources returns every context in the merged kubeconfig, sorted by
+// name, with the file it is loaded from and any files whose definition of the
+// same name is shadowed
+func (c *Client) GetContextSources() ([]models.KubeContext, error) {
+	// Only the service account is available in in-cluster mode
+	if c.config.ConnectionMode == config.ConnectionModeInCluster {
+		return []models.KubeContext{{Name: InClusterContext, Source: inClusterSource}}, nil
+	}
+
+	contexts, err := contextSources(c.config.KubeConfigPaths())
+	if c.contextName == InClusterContext {
+		// Running in auto mode without kubeconfig contexts
+		contexts = append([]models.KubeContext{{Name: InClusterContext, Source: inClusterSource}}, contexts...)
+		return contexts, nil
+	}
+	return contexts, err
+}
+
+// contextSources loads each kubeconfig file separately to find where contexts come from
+func contextSources(paths []string) ([]models.KubeContext, error) {
+	byName := make(map[string]*models.KubeContext)
+	var loaded int
+
+	for _, path := range paths {
+		kubeconfig, err := clientcmd.LoadFromFile(path)
+		if err != nil {
+			log.Printf("Skipping kubeconfig %s: %v", path, err)
+			continue
+		}
+		loaded++
+
+		for name := range kubeconfig.Contexts {
+			if existing, ok := byName[name]; ok {
+				existing.Shadowed = append(existing.Shadowed, path)
+				continue
+			}
+			byName[name] = &models.KubeContext{Name: name, Source: path}
+		}
+	}
+
+	if loaded == 0 && len(paths) > 0 {
+		return nil, fmt.Errorf("failed to load kubeconfig: none of %v could be read", paths)
+	}
+
+	contexts := make([]models.KubeContext, 0, len(byName))
+	for _, kubeContext := range byName {
+		if len(kubeContext.Shadowed) > 0 {
+			log.Printf("Warning: context %q is defined in %s and also in %v; using %s",
+				kubeContext.Name, kubeContext.Source, kubeContext.Shadowed, kubeContext.Source)
+		}
+		contexts = append(contexts, *kubeContext)
+	}
+	sort.Slice(contexts, func(i, j int) bool {
+		return contexts[i].Name < contexts[j].Name
+	})
+
+	return contexts, nil
+}
@@ -0,0 +1,192 @@
+package kubernetes
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattlqx/k8s-tray/internal/config"
+	"github.com/mattlqx/k8s-tray/pkg/models"
+	"k8s.io/client-go/rest"
+)
+
+// writeKubeconfig writes a kubeconfig defining the given contexts
+func writeKubeconfig(t *testing.T, path, currentContext string, contexts ...string) {
+	t.Helper()
+
+	data := "apiVersion: v1\nkind: Config\nclusters:\n- name: cluster\n  cluster:\n    server: https://127.0.0.1:6443\nusers:\n- name: user\n  user: {}\ncontexts:\n"
+	for _, name := range contexts {
+		data += "- name: " + name + "\n  context:\n    cluster: cluster\n    user: user\n"
+	}
+	if currentContext != "" {
+		data += "current-context: " + currentContext + "\n"
+	}
+
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatalf("Failed to write kubeconfig: %v", err)
+	}
+}
+
+func TestKubeconfigPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first")
+	second := filepath.Join(dir, "second")
+	extraDir := filepath.Join(dir, "configs")
+	if err := os.Mkdir(extraDir, 0755); err != nil {
+		t.Fatalf("Failed to create configs dir: %v", err)
+	}
+	extra := filepath.Join(extraDir, "eks.yaml")
+
+	writeKubeconfig(t, first, "dev", "dev", "prod")
+	writeKubeconfig(t, second, "staging", "staging", "prod")
+	writeKubeconfig(t, extra, "", "eks")
+
+	cfg := &config.Config{
+		KubeConfig:    first + string(os.PathListSeparator) + filepath.Join(dir, "missing") + string(os.PathListSeparator) + second,
+		KubeConfigDir: extraDir,
+	}
+	client := NewClient(nil, nil, cfg)
+
+	// The first file to set current-context wins
+	current, err := client.GetCurrentContext()
+	if err != nil {
+		t.Fatalf("Failed to get current context: %v", err)
+	}
+	if current != "dev" {
+		t.Errorf("Expected current context 'dev', got '%s'", current)
+	}
+
+	contexts, err := client.GetAllContexts()
+	if err != nil {
+		t.Fatalf("Failed to get contexts: %v", err)
+	}
+	if expected := []string{"dev", "eks", "prod", "staging"}; !reflect.DeepEqual(contexts, expected) {
+		t.Errorf("Expected contexts %v, got %v", expected, contexts)
+	}
+
+	sources, err := client.GetContextSources()
+	if err != nil {
+		t.Fatalf("Failed to get context sources: %v", err)
+	}
+	expected := []models.KubeContext{
+		{Name: "dev", Source: first},
+		{Name: "eks", Source: extra},
+		{Name: "prod", Source: first, Shadowed: []string{second}},
+		{Name: "staging", Source: second},
+	}
+	if !reflect.DeepEqual(sources, expected) {
+		t.Errorf("Expected sources %+v, got %+v", expected, sources)
+	}
+}
+
+func TestContextSources_NoReadableFiles(t *testing.T) {
+	if _, err := contextSources([]string{filepath.Join(t.TempDir(), "missing")}); err == nil {
+		t.Error("Expected an error when no kubeconfig can be read")
+	}
+}
+
+func TestBuildConfig_ConnectionMode(t *testing.T) {
+	// Make sure in-cluster config is never available during the test
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	t.Setenv("KUBERNETES_SERVICE_PORT", "")
+
+	dir := t.TempDir()
+	kubeconfig := filepath.Join(dir, "config")
+	writeKubeconfig(t, kubeconfig, "dev", "dev", "prod")
+	empty := filepath.Join(dir, "empty")
+	writeKubeconfig(t, empty, "")
+	broken := filepath.Join(dir, "broken")
+	if err := os.WriteFile(broken, []byte("contexts: [\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write kubeconfig: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		mode        string
+		kubeconfig  string
+		context     string
+		expected    string
+		expectError string // Part of the expected error, empty when none
+	}{
+		{name: "Auto uses kubeconfig current-context", mode: config.ConnectionModeAuto, kubeconfig: kubeconfig, expected: "dev"},
+		{name: "Auto uses named context", mode: config.ConnectionModeAuto, kubeconfig: kubeconfig, context: "prod", expected: "prod"},
+		{name: "Auto falls back to in-cluster", mode: config.ConnectionModeAuto, kubeconfig: empty, expectError: "in-cluster"},
+		{name: "Auto falls back to in-cluster without kubeconfig", mode: config.ConnectionModeAuto, kubeconfig: filepath.Join(dir, "missing"), expectError: "in-cluster"},
+		{name: "Auto reports a broken kubeconfig", mode: config.ConnectionModeAuto, kubeconfig: broken, expectError: "failed to load kubeconfig"},
+		{name: "Kubeconfig mode", mode: config.ConnectionModeKubeconfig, kubeconfig: kubeconfig, expected: "dev"},
+		{name: "Kubeconfig mode rejects in-cluster context", mode: config.ConnectionModeKubeconfig, kubeconfig: kubeconfig, context: InClusterContext, expectError: "kubeconfig connection mode"},
+		{name: "In-cluster mode ignores kubeconfig", mode: config.ConnectionModeInCluster, kubeconfig: kubeconfig, expectError: "in-cluster"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{KubeConfig: tt.kubeconfig, ConnectionMode: tt.mode}
+			restConfig, resolved, err := buildConfig(cfg, tt.context)
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Errorf("Expected an error containing '%s', got '%v' (context '%s')", tt.expectError, err, resolved)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to build config: %v", err)
+			}
+			if resolved != tt.expected {
+				t.Errorf("Expected context '%s', got '%s'", tt.expected, resolved)
+			}
+			if restConfig.Host != "https://127.0.0.1:6443" {
+				t.Errorf("Expected host from kubeconfig, got '%s'", restConfig.Host)
+			}
+		})
+	}
+}
+
+func TestApplyContextSettings(t *testing.T) {
+	restConfig := &rest.Config{QPS: 5, Burst: 10}
+	settings := config.ContextSettings{
+		Timeout:           30 * time.Second,
+		QPS:               50,
+		Burst:             100,
+		ImpersonateUser:   "viewer",
+		ImpersonateGroups: []string{"readers"},
+		ProxyURL:          "http://proxy.example.com:3128",
+	}
+
+	if err := applyContextSettings(restConfig, settings); err != nil {
+		t.Fatalf("Failed to apply settings: %v", err)
+	}
+
+	if restConfig.Timeout != 30*time.Second {
+		t.Errorf("Expected timeout 30s, got %v", restConfig.Timeout)
+	}
+	if restConfig.QPS != 50 || restConfig.Burst != 100 {
+		t.Errorf("Expected QPS 50 and burst 100, got %v and %d", restConfig.QPS, restConfig.Burst)
+	}
+	if restConfig.Impersonate.UserName != "viewer" || !reflect.DeepEqual(restConfig.Impersonate.Groups, []string{"readers"}) {
+		t.Errorf("Expected impersonation of viewer/readers, got %+v", restConfig.Impersonate)
+	}
+	if restConfig.Proxy == nil {
+		t.Fatal("Expected proxy to be set")
+	}
+	req, _ := http.NewRequest(http.MethodGet, "https://127.0.0.1:6443", nil)
+	if proxy, err := restConfig.Proxy(req); err != nil || proxy.Host != "proxy.example.com:3128" {
+		t.Errorf("Expected proxy 'proxy.example.com:3128', got %v (%v)", proxy, err)
+	}
+
+	// Empty settings leave the defaults untouched
+	defaults := &rest.Config{QPS: 5, Burst: 10}
+	if err := applyContextSettings(defaults, config.ContextSettings{}); err != nil {
+		t.Fatalf("Failed to apply empty settings: %v", err)
+	}
+	if defaults.QPS != 5 || defaults.Burst != 10 || defaults.Proxy != nil || defaults.Impersonate.UserName != "" {
+		t.Errorf("Expected defaults to be kept, got %+v", defaults)
+	}
+
+	if err := applyContextSettings(&rest.Config{}, config.ContextSettings{ProxyURL: "proxy:3128"}); err == nil {
+		t.Error("Expected an error for a proxy URL without scheme")
+	}
+}
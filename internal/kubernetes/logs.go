@@ -0,0 +1,85 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/mattlqx/k8s-tray/pkg/models"
+)
+
+// GetPodLogs returns the last tailLines lines of each container's log. Containers
+// that have crashed or restarted also get the log of their previous instance.
+// Failures for individual containers are recorded on the result rather than
+// returned, so one missing log does not hide the others. Every request gets
+// its own request timeout, so slow logs do not use up the others' time.
+func (c *Client) GetPodLogs(ctx context.Context, namespace, name string, tailLines int64) ([]models.ContainerLog, error) {
+	reqCtx, cancel := c.requestContext(ctx)
+	pod, err := c.clientset.CoreV1().Pods(namespace).Get(reqCtx, name, metav1.GetOptions{})
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod: %w", err)
+	}
+
+	var logs []models.ContainerLog
+	for _, target := range logTargets(pod) {
+		entry := models.ContainerLog{
+			Container: target.container,
+			Init:      target.init,
+			Previous:  target.previous,
+		}
+
+		reqCtx, cancel := c.requestContext(ctx)
+		content, err := c.clientset.CoreV1().Pods(namespace).GetLogs(name, &corev1.PodLogOptions{
+			Container: target.container,
+			TailLines: &tailLines,
+			Previous:  target.previous,
+		}).DoRaw(reqCtx)
+		cancel()
+		if err != nil {
+			entry.Error = err.Error()
+		} else {
+			entry.Content = string(content)
+		}
+
+		logs = append(logs, entry)
+	}
+
+	return logs, nil
+}
+
+// logTarget identifies one container log to fetch
+type logTarget struct {
+	container string
+	init      bool
+	previous  bool
+}
+
+// logTargets lists the container logs worth fetching for a pod, including the
+// previous instance of containers that have terminated before
+func logTargets(pod *corev1.Pod) []logTarget {
+	statuses := make(map[string]corev1.ContainerStatus)
+	for _, status := range pod.Status.InitContainerStatuses {
+		statuses[status.Name] = status
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		statuses[status.Name] = status
+	}
+
+	var targets []logTarget
+	add := func(containers []corev1.Container, init bool) {
+		for _, container := range containers {
+			status, ok := statuses[container.Name]
+			if ok && (status.RestartCount > 0 || status.LastTerminationState.Terminated != nil) {
+				targets = append(targets, logTarget{container: container.Name, init: init, previous: true})
+			}
+			targets = append(targets, logTarget{container: container.Name, init: init})
+		}
+	}
+	add(pod.Spec.InitContainers, true)
+	add(pod.Spec.Containers, false)
+
+	return targets
+}
@@ -0,0 +1,99 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/mattlqx/k8s-tray/internal/config"
+)
+
+func TestLogTargets(t *testing.T) {
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "migrate"}},
+			Containers:     []corev1.Container{{Name: "api"}, {Name: "sidecar"}},
+		},
+		Status: corev1.PodStatus{
+			InitContainerStatuses: []corev1.ContainerStatus{{Name: "migrate"}},
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name:         "api",
+					RestartCount: 3,
+					LastTerminationState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{Reason: "Error", ExitCode: 1},
+					},
+				},
+				{Name: "sidecar"},
+			},
+		},
+	}
+
+	expected := []logTarget{
+		{container: "migrate", init: true},
+		{container: "api", previous: true},
+		{container: "api"},
+		{container: "sidecar"},
+	}
+
+	result := logTargets(pod)
+	if len(result) != len(expected) {
+		t.Fatalf("Expected %d targets, got %d: %+v", len(expected), len(result), result)
+	}
+	for i := range expected {
+		if result[i] != expected[i] {
+			t.Errorf("Target %d: expected %+v, got %+v", i, expected[i], result[i])
+		}
+	}
+}
+
+func TestGetPodLogs_RequestTimeout(t *testing.T) {
+	pod := newTestPod("default", "api", corev1.PodRunning, true)
+	pod.Spec.Containers = []corev1.Container{{Name: "slow"}, {Name: "fast"}}
+
+	// The slow container's log takes longer than the request timeout
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/log") {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(pod)
+			return
+		}
+		if r.URL.Query().Get("container") == "slow" {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+			return
+		}
+		_, _ = w.Write([]byte("ready\n"))
+	}))
+	t.Cleanup(server.Close)
+
+	clientset, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("Failed to create clientset: %v", err)
+	}
+	client := NewClient(clientset, nil, &config.Config{RequestTimeout: 200 * time.Millisecond})
+
+	logs, err := client.GetPodLogs(context.Background(), "default", "api", 10)
+	if err != nil {
+		t.Fatalf("Failed to get logs: %v", err)
+	}
+	if len(logs) != 2 {
+		t.Fatalf("Expected 2 logs, got %+v", logs)
+	}
+	if logs[0].Error == "" {
+		t.Errorf("Expected the slow container's log to time out, got %+v", logs[0])
+	}
+	if logs[1].Content != "ready\n" {
+		t.Errorf("Expected the fast container's log, got %+v", logs[1])
+	}
+}
@@ -0,0 +1,92 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// bytesPerGB converts memory quantities in bytes to GB
+const bytesPerGB = 1024 * 1024 * 1024
+
+// resourceTotals holds CPU (cores) and memory (GB) amounts
+type resourceTotals struct {
+	cpuCores float64
+	memoryGB float64
+}
+
+// add adds the CPU and memory quantities from a resource list
+func (t *resourceTotals) add(resources corev1.ResourceList) {
+	if cpuQuantity, ok := resources[corev1.ResourceCPU]; ok {
+		t.cpuCores += float64(cpuQuantity.MilliValue()) / 1000.0
+	}
+	if memQuantity, ok := resources[corev1.ResourceMemory]; ok {
+		t.memoryGB += float64(memQuantity.Value()) / bytesPerGB
+	}
+}
+
+// getActualUsage returns actual CPU and memory usage from the metrics.k8s.io API.
+// Node metrics are preferred; pod metrics are summed when nodes cannot be read.
+// The boolean result is false when metrics-server is not available; once
+// found unavailable, it is not asked again until discoveryTTL has passed.
+func (c *Client) getActualUsage(ctx context.Context) (resourceTotals, bool) {
+	var usage resourceTotals
+
+	if c.metrics == nil || c.metricsRecentlyUnavailable() {
+		return usage, false
+	}
+
+	nodeMetrics, err := c.metrics.MetricsV1beta1().NodeMetricses().List(ctx, metav1.ListOptions{})
+	if err == nil {
+		for _, nm := range nodeMetrics.Items {
+			usage.add(nm.Usage)
+		}
+		c.setMetricsAvailable(true, nil)
+		return usage, true
+	}
+
+	podMetrics, podErr := c.metrics.MetricsV1beta1().PodMetricses("").List(ctx, metav1.ListOptions{})
+	if podErr != nil {
+		c.setMetricsAvailable(false, fmt.Errorf("node metrics: %w; pod metrics: %w", err, podErr))
+		return usage, false
+	}
+
+	for _, pm := range podMetrics.Items {
+		for _, container := range pm.Containers {
+			usage.add(container.Usage)
+		}
+	}
+	c.setMetricsAvailable(true, nil)
+	return usage, true
+}
+
+// metricsRecentlyUnavailable reports whether metrics-server was found
+// unavailable within discoveryTTL
+func (c *Client) metricsRecentlyUnavailable() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.metricsChecked && !c.metricsAvailable && time.Since(c.metricsCheckedAt) < discoveryTTL
+}
+
+// setMetricsAvailable records metrics-server availability, logging only on changes
+func (c *Client) setMetricsAvailable(available bool, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.metricsCheckedAt = time.Now()
+	if c.metricsAvailable == available && c.metricsChecked {
+		return
+	}
+	c.metricsChecked = true
+	c.metricsAvailable = available
+
+	if available {
+		log.Printf("Metrics API available, reporting actual resource usage")
+	} else {
+		log.Printf("Metrics API unavailable, reporting requested resources only: %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+
+	"github.com/mattlqx/k8s-tray/internal/config"
+)
+
+// resourceUsage creates a resource list with the given CPU and memory quantities
+func resourceUsage(cpu, memory string) corev1.ResourceList {
+	return corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(cpu),
+		corev1.ResourceMemory: resource.MustParse(memory),
+	}
+}
+
+// newMetricsClientset creates a fake metrics clientset listing the given node
+// and pod metrics; a nil list fails with metrics-server unavailable. The
+// fake's object tracker does not map metrics kinds to their resources, so
+// lists are answered by reactors.
+func newMetricsClientset(nodes *metricsv1beta1.NodeMetricsList, pods *metricsv1beta1.PodMetricsList) *metricsfake.Clientset {
+	clientset := metricsfake.NewSimpleClientset()
+	unavailable := errors.New("the server is currently unable to handle the request")
+	clientset.PrependReactor("list", "nodes", func(k8stesting.Action) (bool, runtime.Object, error) {
+		if nodes == nil {
+			return true, nil, unavailable
+		}
+		return true, nodes, nil
+	})
+	clientset.PrependReactor("list", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+		if pods == nil {
+			return true, nil, unavailable
+		}
+		return true, pods, nil
+	})
+	return clientset
+}
+
+func TestGetActualUsage(t *testing.T) {
+	nodeMetrics := &metricsv1beta1.NodeMetricsList{Items: []metricsv1beta1.NodeMetrics{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}, Usage: resourceUsage("1500m", "2Gi")},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-2"}, Usage: resourceUsage("500m", "1Gi")},
+	}}
+	podMetrics := &metricsv1beta1.PodMetricsList{Items: []metricsv1beta1.PodMetrics{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Containers: []metricsv1beta1.ContainerMetrics{
+				{Name: "app", Usage: resourceUsage("250m", "512Mi")},
+				{Name: "sidecar", Usage: resourceUsage("50m", "512Mi")},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "dns", Namespace: "kube-system"},
+			Containers: []metricsv1beta1.ContainerMetrics{{Name: "coredns", Usage: resourceUsage("100m", "1Gi")}},
+		},
+	}}
+
+	tests := []struct {
+		name        string
+		nodes       *metricsv1beta1.NodeMetricsList
+		pods        *metricsv1beta1.PodMetricsList
+		expectedCPU float64
+		expectedMem float64
+		expectedOK  bool
+	}{
+		{name: "Node metrics", nodes: nodeMetrics, pods: podMetrics, expectedCPU: 2, expectedMem: 3, expectedOK: true},
+		{name: "Pod metrics when nodes cannot be read", pods: podMetrics, expectedCPU: 0.4, expectedMem: 2, expectedOK: true},
+		{name: "Metrics unavailable"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient(nil, newMetricsClientset(tt.nodes, tt.pods), &config.Config{})
+
+			used, ok := client.getActualUsage(context.Background())
+			if ok != tt.expectedOK {
+				t.Fatalf("Expected available=%v, got %v", tt.expectedOK, ok)
+			}
+			if math.Abs(used.cpuCores-tt.expectedCPU) > 0.001 || math.Abs(used.memoryGB-tt.expectedMem) > 0.001 {
+				t.Errorf("Expected %.2f cores and %.2f GB, got %.2f cores and %.2f GB", tt.expectedCPU, tt.expectedMem, used.cpuCores, used.memoryGB)
+			}
+		})
+	}
+}
+
+func TestGetActualUsage_Unavailable(t *testing.T) {
+	metrics := newMetricsClientset(nil, nil)
+	client := NewClient(nil, metrics, &config.Config{})
+
+	for i := 0; i < 3; i++ {
+		if _, ok := client.getActualUsage(context.Background()); ok {
+			t.Fatal("Expected metrics to be unavailable")
+		}
+	}
+
+	// Once found unavailable, metrics-server is not asked again within discoveryTTL
+	if actions := len(metrics.Actions()); actions != 2 {
+		t.Errorf("Expected 2 requests, got %d", actions)
+	}
+
+	// Without a metrics clientset there is nothing to ask
+	if _, ok := NewClient(nil, nil, &config.Config{}).getActualUsage(context.Background()); ok {
+		t.Error("Expected metrics to be unavailable without a metrics clientset")
+	}
+}
@@ -0,0 +1,119 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/mattlqx/k8s-tray/pkg/models"
+)
+
+// nodeRoleLabelPrefix is the label prefix kubeadm and most distributions use for node roles
+const nodeRoleLabelPrefix = "node-role.kubernetes.io/"
+
+// GetNodeStatus returns the condition-aware status of all nodes in the cluster
+func (c *Client) GetNodeStatus(ctx context.Context) (*models.NodeStatus, error) {
+	nodes, err := c.listNodes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	return buildNodeStatus(nodes), nil
+}
+
+// buildNodeStatus summarises node readiness, scheduling and pressure conditions
+func buildNodeStatus(nodes []*corev1.Node) *models.NodeStatus {
+	status := &models.NodeStatus{
+		Total:   len(nodes),
+		Details: make([]models.NodeDetail, 0, len(nodes)),
+	}
+
+	for _, node := range nodes {
+		detail := models.NodeDetail{
+			Name:           node.Name,
+			Unschedulable:  node.Spec.Unschedulable,
+			KubeletVersion: node.Status.NodeInfo.KubeletVersion,
+			Roles:          nodeRoles(node),
+			Age:            time.Since(node.CreationTimestamp.Time),
+		}
+
+		for _, condition := range node.Status.Conditions {
+			switch condition.Type {
+			case corev1.NodeReady:
+				detail.Ready = condition.Status == corev1.ConditionTrue
+			case corev1.NodeMemoryPressure, corev1.NodeDiskPressure, corev1.NodePIDPressure, corev1.NodeNetworkUnavailable:
+				if condition.Status == corev1.ConditionTrue {
+					detail.Conditions = append(detail.Conditions, string(condition.Type))
+				}
+			}
+		}
+
+		if detail.Ready {
+			status.Ready++
+		} else {
+			status.NotReady++
+		}
+		if detail.Unschedulable {
+			status.Cordoned++
+		}
+		pressured := false
+		for _, condition := range detail.Conditions {
+			switch corev1.NodeConditionType(condition) {
+			case corev1.NodeMemoryPressure:
+				status.MemoryPressure++
+				pressured = true
+			case corev1.NodeDiskPressure:
+				status.DiskPressure++
+				pressured = true
+			case corev1.NodePIDPressure:
+				status.PIDPressure++
+				pressured = true
+			case corev1.NodeNetworkUnavailable:
+				status.NetworkUnavailable++
+			}
+		}
+		if pressured {
+			status.UnderPressure++
+		}
+
+		status.Details = append(status.Details, detail)
+	}
+
+	sort.Slice(status.Details, func(i, j int) bool {
+		return status.Details[i].Name < status.Details[j].Name
+	})
+
+	return status
+}
+
+// nodeRoles returns the node's roles from its node-role labels
+func nodeRoles(node *corev1.Node) []string {
+	var roles []string
+	for label := range node.Labels {
+		if role := strings.TrimPrefix(label, nodeRoleLabelPrefix); role != label && role != "" {
+			roles = append(roles, role)
+		}
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+// calculateNodeHealth determines the health contribution of node conditions.
+// Cordoned nodes are expected during maintenance and do not affect health.
+func calculateNodeHealth(nodeStatus *models.NodeStatus) models.HealthStatus {
+	if nodeStatus == nil || nodeStatus.Total == 0 {
+		return models.HealthUnknown
+	}
+	if nodeStatus.NotReady > 0 {
+		return models.HealthCritical
+	}
+	if nodeStatus.MemoryPressure > 0 || nodeStatus.DiskPressure > 0 ||
+		nodeStatus.PIDPressure > 0 || nodeStatus.NetworkUnavailable > 0 {
+		return models.HealthWarning
+	}
+	return models.HealthHealthy
+}
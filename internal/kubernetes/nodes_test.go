@@ -0,0 +1,101 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/mattlqx/k8s-tray/pkg/models"
+)
+
+// testNode creates a node with the given readiness and true conditions
+func testNode(name string, ready bool, conditions ...corev1.NodeConditionType) *corev1.Node {
+	readyStatus := corev1.ConditionFalse
+	if ready {
+		readyStatus = corev1.ConditionTrue
+	}
+
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	node.Status.Conditions = append(node.Status.Conditions, corev1.NodeCondition{Type: corev1.NodeReady, Status: readyStatus})
+	for _, condition := range conditions {
+		node.Status.Conditions = append(node.Status.Conditions, corev1.NodeCondition{Type: condition, Status: corev1.ConditionTrue})
+	}
+	return node
+}
+
+func TestBuildNodeStatus(t *testing.T) {
+	cordoned := testNode("worker-2", true)
+	cordoned.Spec.Unschedulable = true
+	control := testNode("control-1", true)
+	control.Labels = map[string]string{nodeRoleLabelPrefix + "control-plane": "", "kubernetes.io/os": "linux"}
+	control.Status.NodeInfo.KubeletVersion = "v1.28.3"
+
+	status := buildNodeStatus([]*corev1.Node{
+		testNode("worker-3", false),
+		testNode("worker-1", true, corev1.NodeMemoryPressure, corev1.NodeDiskPressure),
+		cordoned,
+		control,
+		testNode("worker-4", true, corev1.NodePIDPressure, corev1.NodeNetworkUnavailable),
+	})
+
+	expected := models.NodeStatus{
+		Total:              5,
+		Ready:              4,
+		NotReady:           1,
+		Cordoned:           1,
+		MemoryPressure:     1,
+		DiskPressure:       1,
+		PIDPressure:        1,
+		NetworkUnavailable: 1,
+		UnderPressure:      2,
+	}
+	counts := *status
+	counts.Details = nil
+	if !reflect.DeepEqual(counts, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, counts)
+	}
+
+	var names []string
+	for _, detail := range status.Details {
+		names = append(names, detail.Name)
+	}
+	if expectedNames := []string{"control-1", "worker-1", "worker-2", "worker-3", "worker-4"}; !reflect.DeepEqual(names, expectedNames) {
+		t.Errorf("Expected nodes sorted by name %v, got %v", expectedNames, names)
+	}
+
+	if detail := status.Details[0]; !reflect.DeepEqual(detail.Roles, []string{"control-plane"}) || detail.KubeletVersion != "v1.28.3" {
+		t.Errorf("Unexpected control plane node %+v", detail)
+	}
+	if conditions := status.Details[1].Conditions; !reflect.DeepEqual(conditions, []string{"MemoryPressure", "DiskPressure"}) {
+		t.Errorf("Expected the true pressure conditions, got %v", conditions)
+	}
+	if !status.Details[2].Unschedulable || status.Details[3].Ready {
+		t.Errorf("Unexpected nodes %+v", status.Details)
+	}
+}
+
+func TestCalculateNodeHealth(t *testing.T) {
+	tests := []struct {
+		name       string
+		nodeStatus *models.NodeStatus
+		expected   models.HealthStatus
+	}{
+		{name: "No status", nodeStatus: nil, expected: models.HealthUnknown},
+		{name: "No nodes", nodeStatus: &models.NodeStatus{}, expected: models.HealthUnknown},
+		{name: "All ready", nodeStatus: &models.NodeStatus{Total: 3, Ready: 3}, expected: models.HealthHealthy},
+		{name: "Cordoned", nodeStatus: &models.NodeStatus{Total: 3, Ready: 3, Cordoned: 1}, expected: models.HealthHealthy},
+		{name: "Memory pressure", nodeStatus: &models.NodeStatus{Total: 3, Ready: 3, MemoryPressure: 1}, expected: models.HealthWarning},
+		{name: "Network unavailable", nodeStatus: &models.NodeStatus{Total: 3, Ready: 3, NetworkUnavailable: 1}, expected: models.HealthWarning},
+		{name: "Not ready", nodeStatus: &models.NodeStatus{Total: 3, Ready: 2, NotReady: 1, DiskPressure: 1}, expected: models.HealthCritical},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := calculateNodeHealth(tt.nodeStatus); result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,301 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/mattlqx/k8s-tray/internal/config"
+	"github.com/mattlqx/k8s-tray/pkg/models"
+)
+
+// defaultNamespace is monitored when nothing else is known about the user's namespaces
+const defaultNamespace = "default"
+
+// Resources listed by the status features
+var (
+	podsResource       = schema.GroupResource{Resource: "pods"}
+	nodesResource      = schema.GroupResource{Resource: "nodes"}
+	namespacesResource = schema.GroupResource{Resource: "namespaces"}
+)
+
+// featureResources lists the namespaced resources each feature needs to list
+var featureResources = map[models.Feature][]schema.GroupResource{
+	models.FeaturePods: {podsResource},
+	models.FeatureWorkloads: {
+		{Group: "apps", Resource: "deployments"},
+		{Group: "apps", Resource: "statefulsets"},
+		{Group: "apps", Resource: "daemonsets"},
+	},
+	models.FeatureJobs: {
+		{Group: "batch", Resource: "jobs"},
+		{Group: "batch", Resource: "cronjobs"},
+	},
+	models.FeatureEvents: {{Resource: "events"}},
+}
+
+// namespacedFeatures are the features probed in the monitored namespaces, in display order
+var namespacedFeatures = []models.Feature{
+	models.FeaturePods, models.FeatureWorkloads, models.FeatureJobs, models.FeatureEvents,
+}
+
+// permissionProbe checks list permissions, caching the rules of each namespace
+type permissionProbe struct {
+	client *Client
+	rules  map[string]*authorizationv1.SubjectRulesReviewStatus
+}
+
+// ProbePermissions checks which status features the current user may access
+// using SelfSubjectAccessReview for cluster-wide access and
+// SelfSubjectRulesReview for namespaces. When pods cannot be listed in all
+// namespaces, the fallback namespaces are monitored instead.
+func (c *Client) ProbePermissions(ctx context.Context) (*models.Permissions, error) {
+	probe := &permissionProbe{client: c, rules: make(map[string]*authorizationv1.SubjectRulesReviewStatus)}
+	perms := &models.Permissions{Denied: make(map[models.Feature]string)}
+
+	// Cluster-scoped resources
+	canListNamespaces, err := probe.canList(ctx, "", namespacesResource)
+	if err != nil {
+		return nil, err
+	}
+	if !canListNamespaces {
+		perms.Denied[models.FeatureNamespaces] = "cannot list namespaces"
+	}
+
+	canListNodes, err := probe.canList(ctx, "", nodesResource)
+	if err != nil {
+		return nil, err
+	}
+	if !canListNodes {
+		perms.Denied[models.FeatureNodes] = "cannot list nodes"
+		perms.Denied[models.FeatureResources] = "cannot list nodes"
+	}
+
+	// Resource usage sums the requests of pods in all namespaces
+	canListAllPods, err := probe.canList(ctx, "", podsResource)
+	if err != nil {
+		return nil, err
+	}
+	if !canListAllPods && canListNodes {
+		perms.Denied[models.FeatureResources] = "cannot list pods in all namespaces"
+	}
+
+	namespaces := []string{c.config.Namespace}
+	if c.config.Namespace == config.AllNamespaces {
+		namespaces = []string{""}
+		if !canListAllPods {
+			perms.Namespaces = c.fallbackNamespaces()
+			namespaces = perms.Namespaces
+		}
+	}
+
+	// Namespaced resources, allowed when they can be listed in any monitored namespace
+	for _, feature := range namespacedFeatures {
+		allowed, err := probe.canListAny(ctx, namespaces, featureResources[feature])
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			perms.Denied[feature] = "cannot list " + formatResources(featureResources[feature])
+		}
+	}
+
+	return perms, nil
+}
+
+// canListAny reports whether all resources can be listed in at least one of the namespaces
+func (p *permissionProbe) canListAny(ctx context.Context, namespaces []string, resources []schema.GroupResource) (bool, error) {
+	for _, namespace := range namespaces {
+		allowed := true
+		for _, resource := range resources {
+			ok, err := p.canList(ctx, namespace, resource)
+			if err != nil {
+				return false, err
+			}
+			if !ok {
+				allowed = false
+				break
+			}
+		}
+		if allowed {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// canList reports whether the resource can be listed in the namespace ("" for
+// all namespaces or cluster-scoped resources). Namespaces are checked against
+// their rules, falling back to an access review when the rules are incomplete.
+func (p *permissionProbe) canList(ctx context.Context, namespace string, resource schema.GroupResource) (bool, error) {
+	if namespace != "" {
+		rules, err := p.namespaceRules(ctx, namespace)
+		if err != nil {
+			return false, err
+		}
+		if rulesAllow(rules.ResourceRules, resource, "list") {
+			return true, nil
+		}
+		if !rules.Incomplete {
+			return false, nil
+		}
+	}
+
+	review := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      "list",
+				Group:     resource.Group,
+				Resource:  resource.Resource,
+			},
+		},
+	}
+	result, err := p.client.clientset.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to review access to %s: %w", resource, err)
+	}
+	return result.Status.Allowed, nil
+}
+
+// namespaceRules returns the user's rules in the namespace
+func (p *permissionProbe) namespaceRules(ctx context.Context, namespace string) (*authorizationv1.SubjectRulesReviewStatus, error) {
+	if rules, ok := p.rules[namespace]; ok {
+		return rules, nil
+	}
+
+	review := &authorizationv1.SelfSubjectRulesReview{
+		Spec: authorizationv1.SelfSubjectRulesReviewSpec{Namespace: namespace},
+	}
+	result, err := p.client.clientset.AuthorizationV1().SelfSubjectRulesReviews().Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to review rules in namespace %s: %w", namespace, err)
+	}
+
+	p.rules[namespace] = &result.Status
+	return &result.Status, nil
+}
+
+// rulesAllow reports whether any rule grants the verb on the resource
+func rulesAllow(rules []authorizationv1.ResourceRule, resource schema.GroupResource, verb string) bool {
+	for _, rule := range rules {
+		if matches(rule.Verbs, verb) && matches(rule.APIGroups, resource.Group) && matches(rule.Resources, resource.Resource) {
+			return true
+		}
+	}
+	return false
+}
+
+// matches reports whether values contains value or the "*" wildcard
+func matches(values []string, value string) bool {
+	for _, v := range values {
+		if v == value || v == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// formatResources joins resource names, e.g. "jobs, cronjobs"
+func formatResources(resources []schema.GroupResource) string {
+	names := make([]string, len(resources))
+	for i, resource := range resources {
+		names[i] = resource.Resource
+	}
+	return strings.Join(names, ", ")
+}
+
+// fallbackNamespaces returns the namespaces monitored instead of all namespaces:
+// the configured list, or the context's default namespace
+func (c *Client) fallbackNamespaces() []string {
+	if len(c.config.FallbackNamespaces) > 0 {
+		return c.config.FallbackNamespaces
+	}
+	if c.defaultNamespace != "" {
+		return []string{c.defaultNamespace}
+	}
+	return []string{defaultNamespace}
+}
+
+// ensurePermissions returns the user's permissions for the configured
+// namespace, probing them on first use and after the namespace changes.
+// It returns nil, allowing everything, when the probe fails.
+func (c *Client) ensurePermissions(ctx context.Context) *models.Permissions {
+	c.mu.RLock()
+	perms, probedNamespace := c.permissions, c.permissionsNamespace
+	c.mu.RUnlock()
+	if perms != nil && probedNamespace == c.config.Namespace {
+		return perms
+	}
+
+	perms, err := c.ProbePermissions(ctx)
+	if err != nil {
+		// Try again on the next refresh
+		fmt.Printf("Warning: failed to check permissions: %v\n", err)
+		return nil
+	}
+
+	c.mu.Lock()
+	c.permissions, c.permissionsNamespace = perms, c.config.Namespace
+	c.mu.Unlock()
+
+	if perms.Degraded() {
+		log.Printf("Limited permissions, disabled features: %v, namespaces: %v", perms.Denied, perms.Namespaces)
+	}
+	return perms
+}
+
+// queryNamespaces returns the namespaces to list for namespace: the
+// fallback namespaces instead of all namespaces when the user may not list
+// cluster-wide, "" for all namespaces, or the namespace itself
+func (c *Client) queryNamespaces(namespace string) []string {
+	if namespace != config.AllNamespaces {
+		return []string{namespace}
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.permissions != nil && len(c.permissions.Namespaces) > 0 {
+		return c.permissions.Namespaces
+	}
+	return []string{""}
+}
+
+// eachNamespace calls fn for each namespace. When there are several, those
+// the user may not access are skipped.
+func eachNamespace(namespaces []string, fn func(namespace string) error) error {
+	for _, namespace := range namespaces {
+		if err := fn(namespace); err != nil {
+			if len(namespaces) > 1 && apierrors.IsForbidden(err) {
+				log.Printf("Skipping namespace %s: %v", namespace, err)
+				continue
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// listInNamespaces lists objects in each namespace and concatenates them,
+// skipping namespaces like eachNamespace
+func listInNamespaces[T any](namespaces []string, list func(namespace string) ([]T, error)) ([]T, error) {
+	var result []T
+	err := eachNamespace(namespaces, func(namespace string) error {
+		items, err := list(namespace)
+		if err != nil {
+			return err
+		}
+		result = append(result, items...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,196 @@
+package kubernetes
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/mattlqx/k8s-tray/internal/config"
+	"github.com/mattlqx/k8s-tray/pkg/models"
+)
+
+// newNamespacedClient creates a client for a user who may only list pods and
+// events in team-a
+func newNamespacedClient(cfg *config.Config, objects ...runtime.Object) *Client {
+	clientset := fake.NewSimpleClientset(objects...)
+	reviewAccess(clientset, func(*authorizationv1.ResourceAttributes) bool { return false })
+	clientset.PrependReactor("create", "selfsubjectrulesreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectRulesReview)
+		if review.Spec.Namespace == "team-a" {
+			review.Status.ResourceRules = []authorizationv1.ResourceRule{
+				{Verbs: []string{"get", "list", "watch"}, APIGroups: []string{""}, Resources: []string{"pods", "pods/log", "events"}},
+			}
+		}
+		return true, review, nil
+	})
+	return NewClient(clientset, nil, cfg)
+}
+
+func TestProbePermissions(t *testing.T) {
+	tests := []struct {
+		name               string
+		cfg                *config.Config
+		expectedNamespaces []string
+	}{
+		{
+			name:               "Configured fallback namespaces",
+			cfg:                &config.Config{Namespace: config.AllNamespaces, FallbackNamespaces: []string{"team-a", "team-b"}},
+			expectedNamespaces: []string{"team-a", "team-b"},
+		},
+		{
+			name:               "Specific namespace",
+			cfg:                &config.Config{Namespace: "team-a"},
+			expectedNamespaces: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newNamespacedClient(tt.cfg)
+			perms, err := client.ProbePermissions(context.Background())
+			if err != nil {
+				t.Fatalf("Failed to probe permissions: %v", err)
+			}
+
+			if !reflect.DeepEqual(perms.Namespaces, tt.expectedNamespaces) {
+				t.Errorf("Expected namespaces %v, got %v", tt.expectedNamespaces, perms.Namespaces)
+			}
+
+			expected := map[models.Feature]bool{
+				models.FeaturePods:       true,
+				models.FeatureEvents:     true,
+				models.FeatureNamespaces: false,
+				models.FeatureNodes:      false,
+				models.FeatureResources:  false,
+				models.FeatureWorkloads:  false,
+				models.FeatureJobs:       false,
+			}
+			for feature, allowed := range expected {
+				if perms.Allowed(feature) != allowed {
+					t.Errorf("Expected %s allowed=%v, got %v (%s)", feature, allowed, perms.Allowed(feature), perms.Denied[feature])
+				}
+			}
+		})
+	}
+}
+
+func TestFallbackNamespaces(t *testing.T) {
+	client := NewClient(fake.NewSimpleClientset(), nil, &config.Config{})
+	if result := client.fallbackNamespaces(); !reflect.DeepEqual(result, []string{"default"}) {
+		t.Errorf("Expected [default], got %v", result)
+	}
+
+	client.defaultNamespace = "team-a"
+	if result := client.fallbackNamespaces(); !reflect.DeepEqual(result, []string{"team-a"}) {
+		t.Errorf("Expected [team-a], got %v", result)
+	}
+
+	client.config.FallbackNamespaces = []string{"team-b", "team-c"}
+	if result := client.fallbackNamespaces(); !reflect.DeepEqual(result, []string{"team-b", "team-c"}) {
+		t.Errorf("Expected [team-b team-c], got %v", result)
+	}
+}
+
+func TestGetClusterStatus_Degraded(t *testing.T) {
+	cfg := &config.Config{Namespace: config.AllNamespaces, Context: "test", ShowMetrics: true, ShowEvents: true}
+	client := newNamespacedClient(cfg,
+		newTestPod("team-a", "api", corev1.PodRunning, true),
+		newTestPod("team-b", "other", corev1.PodFailed, false),
+	)
+	client.defaultNamespace = "team-a"
+
+	status, err := client.GetClusterStatus(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to get cluster status: %v", err)
+	}
+
+	// Only the context's default namespace is monitored
+	if status.PodStatus.Total != 1 || status.HealthStatus != models.HealthHealthy {
+		t.Errorf("Expected 1 healthy pod from team-a, got %d pods (%s)", status.PodStatus.Total, status.HealthStatus)
+	}
+	if status.NodeStatus != nil || status.Resources != nil || status.Workloads != nil || status.Jobs != nil {
+		t.Error("Expected features denied by RBAC to be skipped")
+	}
+	if !status.Permissions.Degraded() {
+		t.Error("Expected permissions to be reported as degraded")
+	}
+
+	namespaces, err := client.GetAllNamespaces(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to get namespaces: %v", err)
+	}
+	if !reflect.DeepEqual(namespaces, []string{"team-a"}) {
+		t.Errorf("Expected fallback namespaces [team-a], got %v", namespaces)
+	}
+}
+
+func TestWatch_Permissions(t *testing.T) {
+	// Only pods and events can be listed, in team-a
+	namespaced := newNamespacedClient(&config.Config{Namespace: config.AllNamespaces, Context: "test"})
+	if _, err := namespaced.Watch(context.Background(), time.Millisecond); err == nil {
+		t.Error("Expected watching to require listing pods in all namespaces")
+	}
+
+	// Everything can be listed in team-a, but jobs not in all namespaces
+	client := newTestClient("team-a", newTestPod("team-a", "api", corev1.PodRunning, true))
+	reviewAccess(client.clientset.(*fake.Clientset), func(attributes *authorizationv1.ResourceAttributes) bool {
+		return attributes.Group != "batch"
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	updates, err := client.Watch(ctx, time.Millisecond)
+	if err != nil {
+		t.Fatalf("Failed to start watching: %v", err)
+	}
+
+	select {
+	case status := <-updates:
+		if status.PodStatus.Total != 1 {
+			t.Errorf("Expected 1 pod, got %d", status.PodStatus.Total)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the first status")
+	}
+
+	cc := client.syncedCache()
+	if !cc.serves(models.FeaturePods) || !cc.serves(models.FeatureWorkloads) || !cc.serves(models.FeatureNamespaces) {
+		t.Error("Expected pods, workloads and namespaces to be cached")
+	}
+	if cc.serves(models.FeatureJobs) {
+		t.Error("Expected jobs to be listed from the API server")
+	}
+}
+
+func TestRulesAllow(t *testing.T) {
+	rules := []authorizationv1.ResourceRule{
+		{Verbs: []string{"list"}, APIGroups: []string{"apps"}, Resources: []string{"deployments"}},
+		{Verbs: []string{"*"}, APIGroups: []string{"batch"}, Resources: []string{"*"}},
+	}
+
+	tests := []struct {
+		resource string
+		group    string
+		expected bool
+	}{
+		{"deployments", "apps", true},
+		{"statefulsets", "apps", false},
+		{"cronjobs", "batch", true},
+		{"pods", "", false},
+	}
+
+	for _, tt := range tests {
+		resource := podsResource
+		resource.Group, resource.Resource = tt.group, tt.resource
+		if result := rulesAllow(rules, resource, "list"); result != tt.expected {
+			t.Errorf("Expected %s.%s allowed=%v, got %v", tt.resource, tt.group, tt.expected, result)
+		}
+	}
+}
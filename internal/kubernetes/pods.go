@@ -0,0 +1,156 @@
+package kubernetes
+
+import (
+	"context"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/mattlqx/k8s-tray/internal/config"
+	"github.com/mattlqx/k8s-tray/pkg/models"
+)
+
+// listPageSize is the number of pods requested per page from the API server
+const listPageSize = 500
+
+// eachPod calls fn for every pod in the namespace ("" for all namespaces),
+// reading the informer cache when synced and otherwise listing the API server
+// a page at a time so that only one page is held in memory. While watching,
+// the cache holds every pod, reduced by slimPod.
+func (c *Client) eachPod(ctx context.Context, namespace string, fn func(pod *corev1.Pod)) error {
+	if cc := c.syncedCache(); cc != nil {
+		pods, err := cc.listPods(namespace)
+		if err != nil {
+			return err
+		}
+		for _, pod := range pods {
+			fn(pod)
+		}
+		return nil
+	}
+
+	// No ResourceVersion: the API server's watch cache ignores Limit for "0"
+	opts := metav1.ListOptions{Limit: listPageSize}
+	for {
+		page, err := c.clientset.CoreV1().Pods(namespace).List(ctx, opts)
+		if err != nil {
+			// An expired continue token fails the refresh; the next one starts over
+			return err
+		}
+		for i := range page.Items {
+			fn(&page.Items[i])
+		}
+
+		if page.Continue == "" {
+			return nil
+		}
+		opts.Continue = page.Continue
+	}
+}
+
+// podUsage sums the resource requests and limits of running and pending pods
+type podUsage struct {
+	requests resourceTotals
+	limits   resourceTotals
+}
+
+// add adds the requests and limits of the pod's containers
+func (u *podUsage) add(pod *corev1.Pod) {
+	// Skip pods that are not running
+	if pod.Status.Phase != corev1.PodRunning && pod.Status.Phase != corev1.PodPending {
+		return
+	}
+
+	// Sum up resource requests and limits from all containers
+	for _, container := range pod.Spec.Containers {
+		u.requests.add(container.Resources.Requests)
+		u.limits.add(container.Resources.Limits)
+	}
+}
+
+// maxPodDetails returns the number of pods listed per category
+func (c *Client) maxPodDetails() int {
+	if c.config.MaxPodDetails > 0 {
+		return c.config.MaxPodDetails
+	}
+	return config.DefaultMaxPodDetails
+}
+
+// podDetailSet keeps the most relevant pods of each category, up to a limit,
+// and counts the rest
+type podDetailSet struct {
+	limit   int
+	details map[models.PodCategory][]models.PodDetail
+	counts  map[models.PodCategory]int
+}
+
+// newPodDetailSet creates a set keeping up to limit pods per category
+func newPodDetailSet(limit int) *podDetailSet {
+	return &podDetailSet{
+		limit:   limit,
+		details: make(map[models.PodCategory][]models.PodDetail),
+		counts:  make(map[models.PodCategory]int),
+	}
+}
+
+// add records a pod, dropping the least relevant ones of its category once
+// twice the limit has been collected
+func (s *podDetailSet) add(detail models.PodDetail) {
+	category := detail.Category()
+	s.counts[category]++
+
+	details := append(s.details[category], detail)
+	if len(details) >= 2*s.limit {
+		details = mostRelevantPods(details, s.limit)
+	}
+	s.details[category] = details
+}
+
+// result returns the kept pods grouped by category and the number of pods
+// left out of each category, or nil when none were
+func (s *podDetailSet) result() ([]models.PodDetail, map[models.PodCategory]int) {
+	categories := make([]models.PodCategory, 0, len(s.details))
+	for category := range s.details {
+		categories = append(categories, category)
+	}
+	sort.Slice(categories, func(i, j int) bool { return categories[i] < categories[j] })
+
+	var result []models.PodDetail
+	var omitted map[models.PodCategory]int
+	for _, category := range categories {
+		kept := mostRelevantPods(s.details[category], s.limit)
+		result = append(result, kept...)
+
+		if n := s.counts[category] - len(kept); n > 0 {
+			if omitted == nil {
+				omitted = make(map[models.PodCategory]int)
+			}
+			omitted[category] = n
+		}
+	}
+	return result, omitted
+}
+
+// mostRelevantPods orders pods by restarts, highest first, then by age,
+// newest first, and returns at most limit of them
+func mostRelevantPods(details []models.PodDetail, limit int) []models.PodDetail {
+	sort.SliceStable(details, func(i, j int) bool {
+		a, b := details[i], details[j]
+		if a.Restarts != b.Restarts {
+			return a.Restarts > b.Restarts
+		}
+		if a.Age != b.Age {
+			return a.Age < b.Age
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+
+	if len(details) > limit {
+		details = details[:limit]
+	}
+	return details
+}
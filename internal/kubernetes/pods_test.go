@@ -0,0 +1,176 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/mattlqx/k8s-tray/internal/config"
+	"github.com/mattlqx/k8s-tray/pkg/models"
+)
+
+// newPaginatingServer serves pod lists in pages of the requested limit, recording each request's query
+func newPaginatingServer(t *testing.T, pods []corev1.Pod, requests *[]url.Values) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		*requests = append(*requests, query)
+
+		start, _ := strconv.Atoi(query.Get("continue"))
+		end := len(pods)
+		if limit, _ := strconv.Atoi(query.Get("limit")); limit > 0 && start+limit < end {
+			end = start + limit
+		}
+
+		list := &corev1.PodList{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "PodList"}, Items: pods[start:end]}
+		if end < len(pods) {
+			list.Continue = strconv.Itoa(end)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(list)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGetPodStatus_Paginated(t *testing.T) {
+	pods := make([]corev1.Pod, 1200)
+	for i := range pods {
+		pods[i] = *newTestPod("default", fmt.Sprintf("pod-%04d", i), corev1.PodRunning, true)
+	}
+
+	var requests []url.Values
+	server := newPaginatingServer(t, pods, &requests)
+	clientset, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("Failed to create clientset: %v", err)
+	}
+	client := NewClient(clientset, nil, &config.Config{Namespace: "default", MaxPodDetails: 10})
+
+	status, err := client.GetPodStatus(context.Background(), "default")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(requests) != 3 {
+		t.Fatalf("Expected 3 pages, got %d", len(requests))
+	}
+	for _, query := range requests {
+		if query.Get("limit") != strconv.Itoa(listPageSize) {
+			t.Errorf("Expected a limit of %d, got '%s'", listPageSize, query.Get("limit"))
+		}
+	}
+	if requests[1].Get("continue") != "500" {
+		t.Errorf("Expected the second page to continue from '500', got '%s'", requests[1].Get("continue"))
+	}
+
+	if status.Total != 1200 || status.RunningReady != 1200 {
+		t.Errorf("Expected 1200 ready pods, got %d of %d", status.RunningReady, status.Total)
+	}
+	if len(status.Details) != 10 {
+		t.Errorf("Expected 10 details, got %d", len(status.Details))
+	}
+	if status.Omitted[models.PodCategoryReady] != 1190 {
+		t.Errorf("Expected 1190 omitted ready pods, got %d", status.Omitted[models.PodCategoryReady])
+	}
+}
+
+func TestPodDetailSet(t *testing.T) {
+	set := newPodDetailSet(2)
+	for i := 0; i < 10; i++ {
+		set.add(models.PodDetail{
+			Name:     fmt.Sprintf("crashing-%d", i),
+			Phase:    "Running",
+			Issue:    models.PodIssueCrashLoop,
+			Restarts: int32(i % 4),
+			Age:      time.Duration(i) * time.Minute,
+		})
+	}
+	set.add(models.PodDetail{Name: "pending", Phase: "Pending"})
+
+	details, omitted := set.result()
+
+	var names []string
+	for _, detail := range details {
+		names = append(names, detail.Name)
+	}
+	// Categories are ordered by name; crash looping pods by restarts, then newest first
+	expected := []string{"crashing-3", "crashing-7", "pending"}
+	if fmt.Sprint(names) != fmt.Sprint(expected) {
+		t.Errorf("Expected %v, got %v", expected, names)
+	}
+
+	if omitted[models.PodCategoryCrashLoop] != 8 {
+		t.Errorf("Expected 8 omitted crash looping pods, got %d", omitted[models.PodCategoryCrashLoop])
+	}
+	if _, ok := omitted[models.PodCategoryPending]; ok {
+		t.Error("Expected no omitted pending pods")
+	}
+}
+
+func TestMaxPodDetails(t *testing.T) {
+	client := &Client{config: &config.Config{}}
+	if result := client.maxPodDetails(); result != config.DefaultMaxPodDetails {
+		t.Errorf("Expected %d, got %d", config.DefaultMaxPodDetails, result)
+	}
+}
+
+func TestSlimPod(t *testing.T) {
+	pod := newTestPod("default", "api", corev1.PodRunning, true)
+	pod.ResourceVersion = "42"
+	pod.Annotations = map[string]string{"kubectl.kubernetes.io/last-applied-configuration": "{}"}
+	pod.ManagedFields = []metav1.ManagedFieldsEntry{{Manager: "kubectl"}}
+	pod.Spec.Volumes = []corev1.Volume{{Name: "config"}}
+	pod.Spec.Containers[0].Env = []corev1.EnvVar{{Name: "MODE", Value: "prod"}}
+	pod.Spec.Containers[0].Resources.Requests = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")}
+
+	obj, err := slimPod(pod)
+	if err != nil {
+		t.Fatalf("Failed to slim pod: %v", err)
+	}
+	slim := obj.(*corev1.Pod)
+
+	if slim.Name != "api" || slim.Namespace != "default" || slim.ResourceVersion != "42" {
+		t.Errorf("Expected the pod's identity to be kept, got %+v", slim.ObjectMeta)
+	}
+	if slim.Annotations != nil || slim.ManagedFields != nil || slim.Spec.Volumes != nil || slim.Spec.Containers[0].Env != nil {
+		t.Errorf("Expected unused fields to be dropped, got %+v", slim)
+	}
+	if cpu := slim.Spec.Containers[0].Resources.Requests.Cpu(); cpu.MilliValue() != 100 {
+		t.Errorf("Expected the 100m CPU request to be kept, got %s", cpu)
+	}
+	if slim.Status.Phase != corev1.PodRunning || len(slim.Status.Conditions) != 1 || len(slim.Status.ContainerStatuses) != len(pod.Status.ContainerStatuses) {
+		t.Errorf("Expected the status to be kept, got %+v", slim.Status)
+	}
+	controller := true
+	failed := newTestPod("default", "migrate-abcde", corev1.PodFailed, false)
+	failed.OwnerReferences = []metav1.OwnerReference{
+		{Kind: "ConfigMap", Name: "migrate-config"},
+		{Kind: "Job", Name: "migrate", Controller: &controller},
+	}
+	obj, err = slimPod(failed)
+	if err != nil {
+		t.Fatalf("Failed to slim pod: %v", err)
+	}
+	slim = obj.(*corev1.Pod)
+
+	if len(slim.OwnerReferences) != 1 || slim.OwnerReferences[0].Kind != "Job" {
+		t.Errorf("Expected only the controller reference to be kept, got %+v", slim.OwnerReferences)
+	}
+	status := &models.PodStatus{}
+	countPod(status, newPodDetailSet(10), slim)
+	if status.Failed != 1 || status.FailedJobPods != 1 {
+		t.Errorf("Expected 1 failed job pod, got %d of %d failed", status.FailedJobPods, status.Failed)
+	}
+}
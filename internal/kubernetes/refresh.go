@@ -0,0 +1,90 @@
+package kubernetes
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/version"
+)
+
+// discoveryTTL is how long the server version and metrics API availability
+// are reused before being checked again
+const discoveryTTL = 10 * time.Minute
+
+// Phases of a refresh recorded in ClusterStatus.Timings
+const (
+	phaseVersion     = "version"
+	phasePermissions = "permissions"
+	phaseContext     = "context"
+	phasePods        = "pods"
+	phaseNodes       = "nodes"
+	phaseMetrics     = "metrics"
+	phaseWorkloads   = "workloads"
+	phaseJobs        = "jobs"
+	phaseEvents      = "events"
+	phaseTotal       = "total"
+)
+
+// phaseTimer records how long each phase of a refresh takes; phases may run concurrently
+type phaseTimer struct {
+	start   time.Time
+	mu      sync.Mutex
+	timings map[string]time.Duration
+}
+
+// newPhaseTimer starts timing a refresh
+func newPhaseTimer() *phaseTimer {
+	return &phaseTimer{start: time.Now(), timings: make(map[string]time.Duration)}
+}
+
+// time runs fn and records its duration under phase
+func (t *phaseTimer) time(phase string, fn func() error) error {
+	start := time.Now()
+	err := fn()
+
+	t.mu.Lock()
+	t.timings[phase] = time.Since(start)
+	t.mu.Unlock()
+	return err
+}
+
+// finish records the total duration and returns all timings
+func (t *phaseTimer) finish() map[string]time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.timings[phaseTotal] = time.Since(t.start)
+	return t.timings
+}
+
+// cachedServerVersion returns the server version, fetching it when it has not
+// been fetched within discoveryTTL. While the informer cache serves reads no
+// other request of a refresh reaches the API server, so the version is then
+// fetched every time to notice a lost cluster.
+func (c *Client) cachedServerVersion(ctx context.Context) (*version.Info, error) {
+	watching := c.syncedCache() != nil
+
+	c.mu.RLock()
+	info, fetchedAt := c.version, c.versionFetchedAt
+	c.mu.RUnlock()
+	if info != nil && time.Since(fetchedAt) < discoveryTTL && !watching {
+		return info, nil
+	}
+
+	info, err := c.serverVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.version, c.versionFetchedAt = info, time.Now()
+	c.mu.Unlock()
+	return info, nil
+}
+
+// forgetServerVersion makes the next refresh fetch the server version again
+func (c *Client) forgetServerVersion() {
+	c.mu.Lock()
+	c.version = nil
+	c.mu.Unlock()
+}
@@ -0,0 +1,217 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/mattlqx/k8s-tray/internal/config"
+)
+
+// DefaultKubeconfigDebounce is how long WatchKubeconfig waits for writes to settle before reloading
+const DefaultKubeconfigDebounce = 500 * time.Millisecond
+
+// KubeconfigChange describes how the merged kubeconfig changed
+type KubeconfigChange struct {
+	ContextsChanged       bool     // Contexts were added or removed
+	CurrentContext        string   // The current-context after the change
+	CurrentContextChanged bool     // current-context was switched, e.g. by kubectl config use-context
+	ChangedContexts       []string // Contexts whose cluster, user or context definition changed
+}
+
+// contextDefinition is everything that determines how a context connects
+type contextDefinition struct {
+	context  *clientcmdapi.Context
+	cluster  *clientcmdapi.Cluster
+	authInfo *clientcmdapi.AuthInfo
+}
+
+// kubeconfigSnapshot is the state of the merged kubeconfig used to detect changes
+type kubeconfigSnapshot struct {
+	currentContext string
+	contexts       map[string]contextDefinition
+}
+
+// WatchKubeconfig watches the configured kubeconfig files and directory and
+// delivers a change whenever the merged kubeconfig changes in a way that
+// matters: contexts added or removed, current-context switched, or a context's
+// credentials or cluster rewritten. The channel is closed when ctx is cancelled.
+func WatchKubeconfig(ctx context.Context, cfg *config.Config, debounce time.Duration) (<-chan KubeconfigChange, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file watcher: %w", err)
+	}
+
+	// Files are usually replaced rather than written in place, so watch their directories
+	dirs := kubeconfigDirs(cfg)
+	for _, dir := range dirs {
+		if err := watcher.Add(dir); err != nil {
+			log.Printf("Failed to watch kubeconfig directory %s: %v", dir, err)
+		}
+	}
+	if len(watcher.WatchList()) == 0 {
+		watcher.Close()
+		return nil, fmt.Errorf("failed to watch any of %v", dirs)
+	}
+
+	previous := takeKubeconfigSnapshot(cfg)
+	changes := make(chan KubeconfigChange, 1)
+
+	go func() {
+		defer close(changes)
+		defer watcher.Close()
+
+		timer := time.NewTimer(debounce)
+		timer.Stop()
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if event.Op == fsnotify.Chmod {
+					continue
+				}
+				// Restart the debounce window on every write
+				timer.Stop()
+				timer.Reset(debounce)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("Kubeconfig watcher error: %v", err)
+			case <-timer.C:
+				current := takeKubeconfigSnapshot(cfg)
+				change, changed := diffKubeconfig(previous, current)
+				previous = current
+				if !changed {
+					continue
+				}
+
+				select {
+				case pending := <-changes:
+					// Fold in the change the consumer has not picked up yet
+					change = mergeKubeconfigChanges(pending, change)
+				default:
+				}
+				changes <- change
+			}
+		}
+	}()
+
+	return changes, nil
+}
+
+// mergeKubeconfigChanges combines two consecutive changes into one
+func mergeKubeconfigChanges(first, second KubeconfigChange) KubeconfigChange {
+	merged := second
+	merged.ContextsChanged = first.ContextsChanged || second.ContextsChanged
+	merged.CurrentContextChanged = first.CurrentContextChanged || second.CurrentContextChanged
+
+	seen := make(map[string]bool)
+	merged.ChangedContexts = nil
+	for _, name := range append(append([]string{}, first.ChangedContexts...), second.ChangedContexts...) {
+		if !seen[name] {
+			seen[name] = true
+			merged.ChangedContexts = append(merged.ChangedContexts, name)
+		}
+	}
+	sort.Strings(merged.ChangedContexts)
+
+	return merged
+}
+
+// kubeconfigDirs returns the existing directories holding the kubeconfig sources
+func kubeconfigDirs(cfg *config.Config) []string {
+	seen := make(map[string]bool)
+	var dirs []string
+	add := func(dir string) {
+		if dir == "" || seen[dir] {
+			return
+		}
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			return
+		}
+		seen[dir] = true
+		dirs = append(dirs, dir)
+	}
+
+	for _, path := range filepath.SplitList(cfg.KubeConfig) {
+		add(filepath.Dir(config.ExpandHome(path)))
+	}
+
+	if dir := config.ExpandHome(cfg.KubeConfigDir); dir != "" {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			add(dir)
+		} else {
+			// A glob pattern; watch the directory it matches in
+			add(filepath.Dir(dir))
+		}
+	}
+
+	return dirs
+}
+
+// takeKubeconfigSnapshot loads the merged kubeconfig; unreadable files yield an empty snapshot
+func takeKubeconfigSnapshot(cfg *config.Config) kubeconfigSnapshot {
+	snapshot := kubeconfigSnapshot{contexts: make(map[string]contextDefinition)}
+
+	kubeconfig, err := loadKubeconfig(cfg)
+	if err != nil {
+		log.Printf("Failed to load kubeconfig: %v", err)
+		return snapshot
+	}
+
+	snapshot.currentContext = kubeconfig.CurrentContext
+	for name, kubeContext := range kubeconfig.Contexts {
+		snapshot.contexts[name] = contextDefinition{
+			context:  kubeContext,
+			cluster:  kubeconfig.Clusters[kubeContext.Cluster],
+			authInfo: kubeconfig.AuthInfos[kubeContext.AuthInfo],
+		}
+	}
+
+	return snapshot
+}
+
+// diffKubeconfig compares two snapshots and reports whether anything relevant changed
+func diffKubeconfig(previous, current kubeconfigSnapshot) (KubeconfigChange, bool) {
+	change := KubeconfigChange{
+		CurrentContext:        current.currentContext,
+		CurrentContextChanged: previous.currentContext != current.currentContext,
+	}
+
+	for name, definition := range current.contexts {
+		old, existed := previous.contexts[name]
+		if !existed {
+			change.ContextsChanged = true
+			change.ChangedContexts = append(change.ChangedContexts, name)
+			continue
+		}
+		if !reflect.DeepEqual(old, definition) {
+			change.ChangedContexts = append(change.ChangedContexts, name)
+		}
+	}
+	for name := range previous.contexts {
+		if _, exists := current.contexts[name]; !exists {
+			change.ContextsChanged = true
+			change.ChangedContexts = append(change.ChangedContexts, name)
+		}
+	}
+	sort.Strings(change.ChangedContexts)
+
+	changed := change.ContextsChanged || change.CurrentContextChanged || len(change.ChangedContexts) > 0
+	return change, changed
+}
@@ -0,0 +1,120 @@
+package kubernetes
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/mattlqx/k8s-tray/internal/config"
+)
+
+func TestDiffKubeconfig(t *testing.T) {
+	definition := func(server, token string) contextDefinition {
+		return contextDefinition{
+			context:  &clientcmdapi.Context{Cluster: "cluster", AuthInfo: "user"},
+			cluster:  &clientcmdapi.Cluster{Server: server},
+			authInfo: &clientcmdapi.AuthInfo{Token: token},
+		}
+	}
+	base := kubeconfigSnapshot{
+		currentContext: "dev",
+		contexts: map[string]contextDefinition{
+			"dev":  definition("https://dev", "a"),
+			"prod": definition("https://prod", "b"),
+		},
+	}
+
+	tests := []struct {
+		name     string
+		current  kubeconfigSnapshot
+		changed  bool
+		expected KubeconfigChange
+	}{
+		{
+			name:     "Unchanged",
+			current:  base,
+			expected: KubeconfigChange{CurrentContext: "dev"},
+		},
+		{
+			name: "Current context switched",
+			current: kubeconfigSnapshot{
+				currentContext: "prod",
+				contexts:       base.contexts,
+			},
+			changed:  true,
+			expected: KubeconfigChange{CurrentContext: "prod", CurrentContextChanged: true},
+		},
+		{
+			name: "Credentials rotated",
+			current: kubeconfigSnapshot{
+				currentContext: "dev",
+				contexts: map[string]contextDefinition{
+					"dev":  definition("https://dev", "rotated"),
+					"prod": definition("https://prod", "b"),
+				},
+			},
+			changed:  true,
+			expected: KubeconfigChange{CurrentContext: "dev", ChangedContexts: []string{"dev"}},
+		},
+		{
+			name: "Context added and removed",
+			current: kubeconfigSnapshot{
+				currentContext: "dev",
+				contexts: map[string]contextDefinition{
+					"dev":     definition("https://dev", "a"),
+					"staging": definition("https://staging", "c"),
+				},
+			},
+			changed: true,
+			expected: KubeconfigChange{
+				CurrentContext: "dev", ContextsChanged: true, ChangedContexts: []string{"prod", "staging"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			change, changed := diffKubeconfig(base, tt.current)
+			if changed != tt.changed {
+				t.Errorf("Expected changed %t, got %t", tt.changed, changed)
+			}
+			if !reflect.DeepEqual(change, tt.expected) {
+				t.Errorf("Expected %+v, got %+v", tt.expected, change)
+			}
+		})
+	}
+}
+
+func TestWatchKubeconfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	writeKubeconfig(t, path, "dev", "dev", "prod")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes, err := WatchKubeconfig(ctx, &config.Config{KubeConfig: path}, 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Failed to watch kubeconfig: %v", err)
+	}
+
+	// Simulate kubectl config use-context
+	writeKubeconfig(t, path, "prod", "dev", "prod")
+
+	select {
+	case change := <-changes:
+		if !change.CurrentContextChanged || change.CurrentContext != "prod" {
+			t.Errorf("Expected current-context change to prod, got %+v", change)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for kubeconfig change")
+	}
+
+	cancel()
+	for range changes {
+		// Drain until the watcher closes the channel
+	}
+}
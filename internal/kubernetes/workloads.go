@@ -0,0 +1,287 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/mattlqx/k8s-tray/pkg/models"
+)
+
+// progressDeadlineExceeded is the Progressing condition reason for a stalled Deployment rollout
+const progressDeadlineExceeded = "ProgressDeadlineExceeded"
+
+// GetWorkloadStatus evaluates the rollout state of Deployments, StatefulSets and
+// DaemonSets in the specified namespace
+func (c *Client) GetWorkloadStatus(ctx context.Context, namespace string) (*models.WorkloadStatus, error) {
+	namespaces := c.queryNamespaces(namespace)
+
+	deployments, err := listInNamespaces(namespaces, func(ns string) ([]*appsv1.Deployment, error) {
+		return c.listDeployments(ctx, ns)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	statefulSets, err := listInNamespaces(namespaces, func(ns string) ([]*appsv1.StatefulSet, error) {
+		return c.listStatefulSets(ctx, ns)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stateful sets: %w", err)
+	}
+	daemonSets, err := listInNamespaces(namespaces, func(ns string) ([]*appsv1.DaemonSet, error) {
+		return c.listDaemonSets(ctx, ns)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list daemon sets: %w", err)
+	}
+
+	details := make([]models.WorkloadDetail, 0, len(deployments)+len(statefulSets)+len(daemonSets))
+	for _, d := range deployments {
+		details = append(details, evaluateDeployment(d))
+	}
+	for _, s := range statefulSets {
+		details = append(details, evaluateStatefulSet(s))
+	}
+	for _, d := range daemonSets {
+		details = append(details, evaluateDaemonSet(d))
+	}
+
+	return buildWorkloadStatus(details), nil
+}
+
+// buildWorkloadStatus counts workloads by state and orders them by kind, namespace and name
+func buildWorkloadStatus(details []models.WorkloadDetail) *models.WorkloadStatus {
+	status := &models.WorkloadStatus{
+		Total:   len(details),
+		Details: details,
+	}
+
+	for _, detail := range details {
+		switch detail.State {
+		case models.WorkloadHealthy:
+			status.Healthy++
+		case models.WorkloadRollingOut:
+			status.RollingOut++
+		case models.WorkloadDegraded:
+			status.Degraded++
+		case models.WorkloadFailed:
+			status.Failed++
+		}
+	}
+
+	sort.Slice(status.Details, func(i, j int) bool {
+		a, b := status.Details[i], status.Details[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+
+	return status
+}
+
+// evaluateDeployment determines a Deployment's rollout state, following the
+// same rules as kubectl rollout status
+func evaluateDeployment(d *appsv1.Deployment) models.WorkloadDetail {
+	detail := models.WorkloadDetail{
+		Kind:      models.KindDeployment,
+		Name:      d.Name,
+		Namespace: d.Namespace,
+		Desired:   replicasOrDefault(d.Spec.Replicas),
+		Updated:   d.Status.UpdatedReplicas,
+		Ready:     d.Status.ReadyReplicas,
+		Available: d.Status.AvailableReplicas,
+	}
+
+	for _, condition := range d.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.Reason == progressDeadlineExceeded {
+			detail.State = models.WorkloadFailed
+			detail.Message = condition.Message
+			return detail
+		}
+	}
+
+	rollingOut := d.Generation > d.Status.ObservedGeneration ||
+		detail.Updated < detail.Desired ||
+		d.Status.Replicas > detail.Updated ||
+		detail.Available < detail.Updated
+
+	detail.State = workloadState(rollingOut, detail.Desired, detail.Available)
+	return detail
+}
+
+// evaluateStatefulSet determines a StatefulSet's rollout state
+func evaluateStatefulSet(s *appsv1.StatefulSet) models.WorkloadDetail {
+	detail := models.WorkloadDetail{
+		Kind:      models.KindStatefulSet,
+		Name:      s.Name,
+		Namespace: s.Namespace,
+		Desired:   replicasOrDefault(s.Spec.Replicas),
+		Updated:   s.Status.UpdatedReplicas,
+		Ready:     s.Status.ReadyReplicas,
+		Available: s.Status.AvailableReplicas,
+	}
+
+	rollingOut := s.Generation > s.Status.ObservedGeneration
+	if s.Spec.UpdateStrategy.Type != appsv1.OnDeleteStatefulSetStrategyType {
+		if partition := statefulSetPartition(s); partition > 0 {
+			// Pods below the partition stay on the current revision, so the
+			// revisions never converge; the rollout is done once the pods
+			// from the partition up are updated, as for kubectl rollout status
+			rollingOut = rollingOut || detail.Updated < max(detail.Desired-partition, 0)
+		} else {
+			rollingOut = rollingOut ||
+				(s.Status.UpdateRevision != "" && s.Status.UpdateRevision != s.Status.CurrentRevision) ||
+				detail.Updated < detail.Desired
+		}
+	}
+
+	detail.State = workloadState(rollingOut, detail.Desired, detail.Available)
+	return detail
+}
+
+// statefulSetPartition returns the ordinal from which pods are updated by a
+// rolling update, 0 when all are
+func statefulSetPartition(s *appsv1.StatefulSet) int32 {
+	if rollingUpdate := s.Spec.UpdateStrategy.RollingUpdate; rollingUpdate != nil && rollingUpdate.Partition != nil {
+		return *rollingUpdate.Partition
+	}
+	return 0
+}
+
+// evaluateDaemonSet determines a DaemonSet's rollout state; desired counts
+// come from the nodes the DaemonSet should be scheduled on
+func evaluateDaemonSet(d *appsv1.DaemonSet) models.WorkloadDetail {
+	detail := models.WorkloadDetail{
+		Kind:      models.KindDaemonSet,
+		Name:      d.Name,
+		Namespace: d.Namespace,
+		Desired:   d.Status.DesiredNumberScheduled,
+		Updated:   d.Status.UpdatedNumberScheduled,
+		Ready:     d.Status.NumberReady,
+		Available: d.Status.NumberAvailable,
+	}
+
+	rollingOut := d.Generation > d.Status.ObservedGeneration
+	if d.Spec.UpdateStrategy.Type != appsv1.OnDeleteDaemonSetStrategyType {
+		rollingOut = rollingOut || detail.Updated < detail.Desired
+	}
+
+	detail.State = workloadState(rollingOut, detail.Desired, detail.Available)
+
+	var problems []string
+	if missing := detail.Desired - detail.Available; missing > 0 && detail.State == models.WorkloadDegraded {
+		problems = append(problems, fmt.Sprintf("missing on %d node(s)", missing))
+	}
+	if d.Status.NumberMisscheduled > 0 {
+		problems = append(problems, fmt.Sprintf("running on %d node(s) it should not be", d.Status.NumberMisscheduled))
+	}
+	detail.Message = strings.Join(problems, ", ")
+	return detail
+}
+
+// workloadState classifies a workload that is not stalled
+func workloadState(rollingOut bool, desired, available int32) models.WorkloadState {
+	switch {
+	case rollingOut:
+		return models.WorkloadRollingOut
+	case available < desired:
+		return models.WorkloadDegraded
+	default:
+		return models.WorkloadHealthy
+	}
+}
+
+// replicasOrDefault returns the replica count, defaulting to 1 as the API server does
+func replicasOrDefault(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+// calculateWorkloadHealth determines the health contribution of workloads.
+// Rollouts in progress are normal; stalled rollouts and workloads with no
+// available replicas are critical.
+func calculateWorkloadHealth(workloadStatus *models.WorkloadStatus) models.HealthStatus {
+	health := models.HealthHealthy
+	for _, detail := range workloadStatus.Details {
+		switch detail.State {
+		case models.WorkloadFailed:
+			return models.HealthCritical
+		case models.WorkloadDegraded:
+			if detail.Available == 0 {
+				return models.HealthCritical
+			}
+			health = models.HealthWarning
+		}
+	}
+	return health
+}
+
+// listDeployments returns deployments from the informer cache when synced, otherwise from the API server
+func (c *Client) listDeployments(ctx context.Context, namespace string) ([]*appsv1.Deployment, error) {
+	if cc := c.syncedCache(); cc.serves(models.FeatureWorkloads) {
+		return cc.listDeployments(namespace)
+	}
+
+	list, err := c.clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{
+		ResourceVersion: "0",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*appsv1.Deployment, len(list.Items))
+	for i := range list.Items {
+		result[i] = &list.Items[i]
+	}
+	return result, nil
+}
+
+// listStatefulSets returns stateful sets from the informer cache when synced, otherwise from the API server
+func (c *Client) listStatefulSets(ctx context.Context, namespace string) ([]*appsv1.StatefulSet, error) {
+	if cc := c.syncedCache(); cc.serves(models.FeatureWorkloads) {
+		return cc.listStatefulSets(namespace)
+	}
+
+	list, err := c.clientset.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{
+		ResourceVersion: "0",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*appsv1.StatefulSet, len(list.Items))
+	for i := range list.Items {
+		result[i] = &list.Items[i]
+	}
+	return result, nil
+}
+
+// listDaemonSets returns daemon sets from the informer cache when synced, otherwise from the API server
+func (c *Client) listDaemonSets(ctx context.Context, namespace string) ([]*appsv1.DaemonSet, error) {
+	if cc := c.syncedCache(); cc.serves(models.FeatureWorkloads) {
+		return cc.listDaemonSets(namespace)
+	}
+
+	list, err := c.clientset.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{
+		ResourceVersion: "0",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*appsv1.DaemonSet, len(list.Items))
+	for i := range list.Items {
+		result[i] = &list.Items[i]
+	}
+	return result, nil
+}
@@ -0,0 +1,249 @@
+package kubernetes
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/mattlqx/k8s-tray/pkg/models"
+)
+
+// int32Ptr returns a pointer to n
+func int32Ptr(n int32) *int32 {
+	return &n
+}
+
+func TestEvaluateDeployment(t *testing.T) {
+	stalled := appsv1.DeploymentCondition{
+		Type:    appsv1.DeploymentProgressing,
+		Reason:  progressDeadlineExceeded,
+		Message: `ReplicaSet "api-7d9f" has timed out progressing.`,
+	}
+
+	tests := []struct {
+		name            string
+		replicas        *int32
+		generation      int64
+		status          appsv1.DeploymentStatus
+		expected        models.WorkloadState
+		expectedMessage string
+	}{
+		{
+			name:     "Healthy",
+			replicas: int32Ptr(3),
+			status:   appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 3, ReadyReplicas: 3, AvailableReplicas: 3},
+			expected: models.WorkloadHealthy,
+		},
+		{
+			name:     "Default replicas",
+			status:   appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, ReadyReplicas: 1, AvailableReplicas: 1},
+			expected: models.WorkloadHealthy,
+		},
+		{
+			name:       "Spec not observed yet",
+			replicas:   int32Ptr(3),
+			generation: 2,
+			status:     appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 3},
+			expected:   models.WorkloadRollingOut,
+		},
+		{
+			name:     "Old replicas left",
+			replicas: int32Ptr(3),
+			status:   appsv1.DeploymentStatus{Replicas: 4, UpdatedReplicas: 3, AvailableReplicas: 3},
+			expected: models.WorkloadRollingOut,
+		},
+		{
+			name:     "Updated replicas not available yet",
+			replicas: int32Ptr(3),
+			status:   appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 3, ReadyReplicas: 2, AvailableReplicas: 2},
+			expected: models.WorkloadRollingOut,
+		},
+		{
+			name:            "Progress deadline exceeded",
+			replicas:        int32Ptr(3),
+			status:          appsv1.DeploymentStatus{Replicas: 4, UpdatedReplicas: 1, AvailableReplicas: 3, Conditions: []appsv1.DeploymentCondition{stalled}},
+			expected:        models.WorkloadFailed,
+			expectedMessage: stalled.Message,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default", Generation: tt.generation},
+				Spec:       appsv1.DeploymentSpec{Replicas: tt.replicas},
+				Status:     tt.status,
+			}
+			detail := evaluateDeployment(d)
+			if detail.State != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, detail.State)
+			}
+			if detail.Message != tt.expectedMessage {
+				t.Errorf("Expected '%s', got '%s'", tt.expectedMessage, detail.Message)
+			}
+		})
+	}
+}
+
+func TestEvaluateStatefulSet(t *testing.T) {
+	tests := []struct {
+		name      string
+		partition *int32
+		onDelete  bool
+		status    appsv1.StatefulSetStatus
+		expected  models.WorkloadState
+	}{
+		{
+			name:     "Healthy",
+			status:   appsv1.StatefulSetStatus{UpdatedReplicas: 3, ReadyReplicas: 3, AvailableReplicas: 3, CurrentRevision: "db-1", UpdateRevision: "db-1"},
+			expected: models.WorkloadHealthy,
+		},
+		{
+			name:     "Revisions differ",
+			status:   appsv1.StatefulSetStatus{UpdatedReplicas: 1, AvailableReplicas: 3, CurrentRevision: "db-1", UpdateRevision: "db-2"},
+			expected: models.WorkloadRollingOut,
+		},
+		{
+			name:      "Partitioned rollout in progress",
+			partition: int32Ptr(1),
+			status:    appsv1.StatefulSetStatus{UpdatedReplicas: 1, AvailableReplicas: 3, CurrentRevision: "db-1", UpdateRevision: "db-2"},
+			expected:  models.WorkloadRollingOut,
+		},
+		{
+			name:      "Partitioned rollout done",
+			partition: int32Ptr(1),
+			status:    appsv1.StatefulSetStatus{UpdatedReplicas: 2, AvailableReplicas: 3, CurrentRevision: "db-1", UpdateRevision: "db-2"},
+			expected:  models.WorkloadHealthy,
+		},
+		{
+			name:      "Partition above the replicas",
+			partition: int32Ptr(5),
+			status:    appsv1.StatefulSetStatus{AvailableReplicas: 3, CurrentRevision: "db-1", UpdateRevision: "db-2"},
+			expected:  models.WorkloadHealthy,
+		},
+		{
+			name:     "On delete leaves pods on the old revision",
+			onDelete: true,
+			status:   appsv1.StatefulSetStatus{UpdatedReplicas: 0, AvailableReplicas: 3, CurrentRevision: "db-1", UpdateRevision: "db-2"},
+			expected: models.WorkloadHealthy,
+		},
+		{
+			name:     "Degraded",
+			status:   appsv1.StatefulSetStatus{UpdatedReplicas: 3, AvailableReplicas: 2, CurrentRevision: "db-1", UpdateRevision: "db-1"},
+			expected: models.WorkloadDegraded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+				Spec:       appsv1.StatefulSetSpec{Replicas: int32Ptr(3)},
+				Status:     tt.status,
+			}
+			if tt.onDelete {
+				s.Spec.UpdateStrategy.Type = appsv1.OnDeleteStatefulSetStrategyType
+			} else if tt.partition != nil {
+				s.Spec.UpdateStrategy.RollingUpdate = &appsv1.RollingUpdateStatefulSetStrategy{Partition: tt.partition}
+			}
+
+			if detail := evaluateStatefulSet(s); detail.State != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, detail.State)
+			}
+		})
+	}
+}
+
+func TestEvaluateDaemonSet(t *testing.T) {
+	tests := []struct {
+		name            string
+		status          appsv1.DaemonSetStatus
+		expected        models.WorkloadState
+		expectedMessage string
+	}{
+		{
+			name:     "Healthy",
+			status:   appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberReady: 3, NumberAvailable: 3},
+			expected: models.WorkloadHealthy,
+		},
+		{
+			name:     "Rolling out",
+			status:   appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, UpdatedNumberScheduled: 1, NumberAvailable: 3},
+			expected: models.WorkloadRollingOut,
+		},
+		{
+			name:            "Missing",
+			status:          appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberAvailable: 1},
+			expected:        models.WorkloadDegraded,
+			expectedMessage: "missing on 2 node(s)",
+		},
+		{
+			name:            "Misscheduled",
+			status:          appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberAvailable: 3, NumberMisscheduled: 1},
+			expected:        models.WorkloadHealthy,
+			expectedMessage: "running on 1 node(s) it should not be",
+		},
+		{
+			name:            "Missing and misscheduled",
+			status:          appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberAvailable: 2, NumberMisscheduled: 1},
+			expected:        models.WorkloadDegraded,
+			expectedMessage: "missing on 1 node(s), running on 1 node(s) it should not be",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "kube-system"},
+				Status:     tt.status,
+			}
+			detail := evaluateDaemonSet(d)
+			if detail.State != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, detail.State)
+			}
+			if detail.Message != tt.expectedMessage {
+				t.Errorf("Expected '%s', got '%s'", tt.expectedMessage, detail.Message)
+			}
+		})
+	}
+}
+
+func TestCalculateWorkloadHealth(t *testing.T) {
+	tests := []struct {
+		name     string
+		details  []models.WorkloadDetail
+		expected models.HealthStatus
+	}{
+		{name: "No workloads", details: nil, expected: models.HealthHealthy},
+		{
+			name:     "Rolling out",
+			details:  []models.WorkloadDetail{{State: models.WorkloadHealthy}, {State: models.WorkloadRollingOut}},
+			expected: models.HealthHealthy,
+		},
+		{
+			name:     "Degraded",
+			details:  []models.WorkloadDetail{{State: models.WorkloadDegraded, Desired: 3, Available: 2}},
+			expected: models.HealthWarning,
+		},
+		{
+			name:     "None available",
+			details:  []models.WorkloadDetail{{State: models.WorkloadDegraded, Desired: 3, Available: 0}},
+			expected: models.HealthCritical,
+		},
+		{
+			name:     "Stalled rollout",
+			details:  []models.WorkloadDetail{{State: models.WorkloadDegraded, Desired: 3, Available: 2}, {State: models.WorkloadFailed}},
+			expected: models.HealthCritical,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := buildWorkloadStatus(tt.details)
+			if result := calculateWorkloadHealth(status); result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/godbus/dbus/v5"
+
+	"github.com/mattlqx/k8s-tray/pkg/models"
+)
+
+// The freedesktop notification service
+const (
+	dbusDestination = "org.freedesktop.Notifications"
+	dbusPath        = "/org/freedesktop/Notifications"
+	dbusNotify      = "org.freedesktop.Notifications.Notify"
+)
+
+// Urgency levels of the freedesktop notification spec
+const (
+	urgencyLow      byte = 0
+	urgencyNormal   byte = 1
+	urgencyCritical byte = 2
+)
+
+// DBusBackend shows desktop notifications through the freedesktop
+// org.freedesktop.Notifications D-Bus interface
+type DBusBackend struct {
+	appName string
+}
+
+// NewDesktopBackend creates the platform's desktop notification backend
+func NewDesktopBackend(appName string) Backend {
+	return &DBusBackend{appName: appName}
+}
+
+// Send shows the notification on the session bus
+func (b *DBusBackend) Send(ctx context.Context, notification Notification) error {
+	// The shared connection is also used by the tray icon and must not be closed
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return fmt.Errorf("failed to connect to session bus: %w", err)
+	}
+
+	call := conn.Object(dbusDestination, dbusPath).CallWithContext(ctx, dbusNotify, 0, notifyArgs(b.appName, notification)...)
+	if call.Err != nil {
+		return fmt.Errorf("failed to call %s: %w", dbusNotify, call.Err)
+	}
+	return nil
+}
+
+// notifyArgs returns the arguments of the Notify method: app name, replaced
+// notification ID, icon, summary, body, actions, hints and expiry (-1 for the
+// server's default)
+func notifyArgs(appName string, notification Notification) []interface{} {
+	hints := map[string]dbus.Variant{
+		"urgency": dbus.MakeVariant(urgency(notification.Severity)),
+	}
+	return []interface{}{
+		appName, uint32(0), "", notification.Title, notification.Body, []string{}, hints, int32(-1),
+	}
+}
+
+// urgency maps a severity to a notification urgency; recoveries are low urgency
+func urgency(severity models.HealthStatus) byte {
+	switch severity {
+	case models.HealthCritical:
+		return urgencyCritical
+	case models.HealthWarning:
+		return urgencyNormal
+	default:
+		return urgencyLow
+	}
+}
@@ -0,0 +1,28 @@
+package notify
+
+import (
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+
+	"github.com/mattlqx/k8s-tray/pkg/models"
+)
+
+func TestNotifyArgs(t *testing.T) {
+	args := notifyArgs("K8s Tray", Notification{Title: "prod: Critical", Body: "api entered CrashLoopBackOff", Severity: models.HealthCritical})
+
+	if len(args) != 8 {
+		t.Fatalf("Expected 8 arguments, got %d", len(args))
+	}
+	if args[0] != "K8s Tray" || args[3] != "prod: Critical" || args[4] != "api entered CrashLoopBackOff" {
+		t.Errorf("Unexpected app name, summary or body: %v", args)
+	}
+
+	hints := args[6].(map[string]dbus.Variant)
+	if urgency := hints["urgency"].Value(); urgency != urgencyCritical {
+		t.Errorf("Expected urgency %d, got %v", urgencyCritical, urgency)
+	}
+	if args[7] != int32(-1) {
+		t.Errorf("Expected the default expiry, got %v", args[7])
+	}
+}
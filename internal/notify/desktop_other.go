@@ -0,0 +1,22 @@
+//go:build !linux
+
+package notify
+
+import (
+	"context"
+	"log"
+)
+
+// logBackend writes notifications to the log on platforms without a desktop backend
+type logBackend struct{}
+
+// NewDesktopBackend creates the platform's desktop notification backend
+func NewDesktopBackend(string) Backend {
+	return logBackend{}
+}
+
+// Send logs the notification
+func (logBackend) Send(_ context.Context, notification Notification) error {
+	log.Printf("Notification: %s: %s", notification.Title, notification.Body)
+	return nil
+}
@@ -0,0 +1,114 @@
+// Package notify delivers notifications about changes in cluster health
+package notify
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/mattlqx/k8s-tray/pkg/models"
+)
+
+// Notification is a message about a change in cluster health
+type Notification struct {
+	Title    string
+	Body     string
+	Severity models.HealthStatus // Healthy for recoveries, Critical for outages
+
+	// About reaching the cluster from this machine rather than the cluster's health
+	Connection bool
+
+	// Details for backends that forward the whole picture, like webhooks
+	Context string
+	Time    time.Time
+	Status  *models.ClusterStatus // Nil when the cluster could not be reached
+	Changes []models.Change       // Changes found by the refresh that raised the notification
+}
+
+// Backend delivers notifications, e.g. to the desktop
+type Backend interface {
+	Send(ctx context.Context, notification Notification) error
+}
+
+// Runner is implemented by backends that deliver in the background; Notifier
+// runs them alongside its own delivery
+type Runner interface {
+	Run(ctx context.Context)
+}
+
+// Delivery settings
+const (
+	queueSize   = 32               // Notifications waiting for delivery per backend
+	sendTimeout = 30 * time.Second // Longest one backend may take to accept a notification
+)
+
+// Notifier delivers notifications to its backends in the background so that
+// a slow backend never delays a refresh. Each backend has its own queue, so
+// that it does not delay the others either.
+type Notifier struct {
+	backends []Backend
+	queues   []chan Notification
+}
+
+// New creates a notifier delivering through backends; call Run to start delivery
+func New(backends ...Backend) *Notifier {
+	n := &Notifier{backends: backends}
+	for range backends {
+		n.queues = append(n.queues, make(chan Notification, queueSize))
+	}
+	return n
+}
+
+// Notify queues a notification for each backend, dropping it for backends
+// whose queue is full
+func (n *Notifier) Notify(notification Notification) {
+	for i, queue := range n.queues {
+		select {
+		case queue <- notification:
+		default:
+			log.Printf("Dropping notification %q for %T, too many pending", notification.Title, n.backends[i])
+		}
+	}
+}
+
+// Run delivers queued notifications until ctx is cancelled, then logs the
+// notifications left undelivered
+func (n *Notifier) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i, backend := range n.backends {
+		if runner, ok := backend.(Runner); ok {
+			go runner.Run(ctx)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			deliver(ctx, backend, n.queues[i])
+		}()
+	}
+	wg.Wait()
+}
+
+// deliver sends the notifications queued for backend until ctx is cancelled
+func deliver(ctx context.Context, backend Backend, queue chan Notification) {
+	for {
+		select {
+		case <-ctx.Done():
+			for {
+				select {
+				case notification := <-queue:
+					log.Printf("Dropping notification %q for %T, shutting down", notification.Title, backend)
+				default:
+					return
+				}
+			}
+		case notification := <-queue:
+			sendCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+			if err := backend.Send(sendCtx, notification); err != nil {
+				log.Printf("Failed to send notification %q: %v", notification.Title, err)
+			}
+			cancel()
+		}
+	}
+}
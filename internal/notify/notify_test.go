@@ -0,0 +1,115 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeBackend records notifications instead of showing them
+type fakeBackend struct {
+	mu   sync.Mutex
+	sent []Notification
+	err  error
+}
+
+func (f *fakeBackend) Send(_ context.Context, notification Notification) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = append(f.sent, notification)
+	return f.err
+}
+
+func (f *fakeBackend) titles() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	titles := make([]string, len(f.sent))
+	for i, notification := range f.sent {
+		titles[i] = notification.Title
+	}
+	return titles
+}
+
+// waitFor polls until the backend has received count notifications
+func (f *fakeBackend) waitFor(t *testing.T, count int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for len(f.titles()) < count {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected %d notifications, got %d", count, len(f.titles()))
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestNotifier(t *testing.T) {
+	backend := &fakeBackend{err: errors.New("no notification daemon")}
+	notifier := New(backend)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go notifier.Run(ctx)
+
+	// Failed deliveries are logged and do not stop later ones
+	notifier.Notify(Notification{Title: "first"})
+	notifier.Notify(Notification{Title: "second"})
+	backend.waitFor(t, 2)
+
+	titles := backend.titles()
+	if titles[0] != "first" || titles[1] != "second" {
+		t.Errorf("Expected notifications in order, got %v", titles)
+	}
+}
+
+// blockingBackend accepts notifications only once its context is done
+type blockingBackend struct {
+	deadlines chan bool
+}
+
+func (b *blockingBackend) Send(ctx context.Context, _ Notification) error {
+	_, ok := ctx.Deadline()
+	b.deadlines <- ok
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestNotifier_SlowBackend(t *testing.T) {
+	slow := &blockingBackend{deadlines: make(chan bool, 1)}
+	fast := &fakeBackend{}
+	notifier := New(slow, fast)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go notifier.Run(ctx)
+
+	// A backend that hangs does not hold up the others
+	notifier.Notify(Notification{Title: "first"})
+	notifier.Notify(Notification{Title: "second"})
+	fast.waitFor(t, 2)
+
+	if hasDeadline := <-slow.deadlines; !hasDeadline {
+		t.Error("Expected sends to have a deadline")
+	}
+}
+
+func TestNotifier_DropsWhenFull(t *testing.T) {
+	backend := &fakeBackend{}
+	notifier := New(backend)
+
+	// Nothing is delivered until Run starts
+	for i := 0; i < queueSize+5; i++ {
+		notifier.Notify(Notification{Title: "update"})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go notifier.Run(ctx)
+	backend.waitFor(t, queueSize)
+
+	time.Sleep(10 * time.Millisecond)
+	if sent := len(backend.titles()); sent != queueSize {
+		t.Errorf("Expected %d notifications, got %d", queueSize, sent)
+	}
+}
@@ -0,0 +1,415 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/mattlqx/k8s-tray/internal/config"
+	"github.com/mattlqx/k8s-tray/pkg/models"
+)
+
+// Webhook delivery settings
+const (
+	webhookTimeout    = 10 * time.Second
+	maxQueuedAlerts   = 100 // Oldest alerts are dropped beyond this while the endpoint is unreachable
+	initialRetryDelay = 5 * time.Second
+	maxRetryDelay     = 5 * time.Minute
+	maxAlertChanges   = 10 // Changes listed in Slack and Teams messages
+)
+
+// Webhook posts alerts to an HTTP endpoint. Alerts are queued in memory and
+// retried with exponential backoff, so that none are lost while offline.
+type Webhook struct {
+	url              string
+	host             string // Logged instead of the URL, which often embeds a secret token
+	format           string
+	template         *template.Template
+	minSeverity      models.HealthStatus
+	connectionAlerts bool
+	headers          map[string]string
+	client           *http.Client
+	retryDelay       time.Duration // Delay before the first retry, doubled after each failure
+
+	mu      sync.Mutex
+	pending []webhookAlert
+	alerted map[alertKey]bool // Alerts sent whose recovery has not been sent yet
+	wake    chan struct{}
+}
+
+// alertKey identifies what a recovery recovers from: the health of a
+// context, or reaching it
+type alertKey struct {
+	context    string
+	connection bool
+}
+
+// webhookAlert is a rendered alert waiting to be posted
+type webhookAlert struct {
+	title string
+	body  []byte
+}
+
+// alertPayload is the body of json webhooks and the data of template webhooks
+type alertPayload struct {
+	Context  string                `json:"context"`
+	Title    string                `json:"title"`
+	Message  string                `json:"message"`
+	Severity string                `json:"severity"`
+	Time     time.Time             `json:"time"`
+	Status   *models.ClusterStatus `json:"status,omitempty"`
+	Changes  []models.Change       `json:"changes,omitempty"`
+}
+
+// NewWebhook creates a webhook backend; call Run, or add it to a Notifier, to
+// start delivery
+func NewWebhook(cfg config.Webhook) (*Webhook, error) {
+	endpoint, err := url.Parse(cfg.URL)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid webhook URL %q", cfg.URL)
+	}
+
+	w := &Webhook{
+		url:              cfg.URL,
+		host:             endpoint.Host,
+		format:           cfg.Format,
+		minSeverity:      parseSeverity(cfg.MinSeverity),
+		connectionAlerts: cfg.ConnectionAlerts,
+		headers:          cfg.Headers,
+		client:           &http.Client{Timeout: webhookTimeout},
+		retryDelay:       initialRetryDelay,
+		alerted:          make(map[alertKey]bool),
+		wake:             make(chan struct{}, 1),
+	}
+
+	if cfg.Format == config.WebhookFormatTemplate {
+		if cfg.Template == "" {
+			return nil, fmt.Errorf("webhook %s has no template", endpoint.Host)
+		}
+		w.template, err = template.New(endpoint.Host).Funcs(template.FuncMap{"json": toJSON}).Parse(cfg.Template)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse template of webhook %s: %w", endpoint.Host, err)
+		}
+	}
+
+	return w, nil
+}
+
+// parseSeverity converts a configured severity to a health status
+func parseSeverity(severity string) models.HealthStatus {
+	switch severity {
+	case config.SeverityHealthy:
+		return models.HealthHealthy
+	case config.SeverityCritical:
+		return models.HealthCritical
+	default:
+		return models.HealthWarning
+	}
+}
+
+// toJSON encodes a value for embedding in a JSON template, e.g. {{json .Message}}
+func toJSON(value any) (string, error) {
+	data, err := json.Marshal(value)
+	return string(data), err
+}
+
+// Send queues the notification when it is at least as severe as the webhook's
+// minimum, or when it recovers from an alert that was sent. Connection
+// notifications are only sent when the webhook has connection alerts enabled.
+func (w *Webhook) Send(_ context.Context, notification Notification) error {
+	if notification.Connection && !w.connectionAlerts {
+		return nil
+	}
+
+	key := alertKey{context: notification.Context, connection: notification.Connection}
+	recovery := notification.Severity == models.HealthHealthy
+
+	w.mu.Lock()
+	alerted := w.alerted[key]
+	w.mu.Unlock()
+	if !(recovery && alerted) && models.WorstHealth(notification.Severity, w.minSeverity) != notification.Severity {
+		return nil
+	}
+
+	body, err := w.render(notification)
+	if err != nil {
+		return fmt.Errorf("failed to render alert for webhook %s: %w", w.host, err)
+	}
+
+	w.mu.Lock()
+	if recovery {
+		delete(w.alerted, key)
+	} else {
+		w.alerted[key] = true
+	}
+	w.pending = append(w.pending, webhookAlert{title: notification.Title, body: body})
+	w.dropOverflow()
+	w.mu.Unlock()
+
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// dropOverflow drops the oldest alerts beyond maxQueuedAlerts; w.mu must be held
+func (w *Webhook) dropOverflow() {
+	for len(w.pending) > maxQueuedAlerts {
+		log.Printf("Dropping alert %q for webhook %s, too many pending", w.pending[0].title, w.host)
+		w.pending = w.pending[1:]
+	}
+}
+
+// Run posts queued alerts in order until ctx is cancelled, retrying failed
+// posts with exponential backoff. Alerts still pending then are logged as dropped.
+func (w *Webhook) Run(ctx context.Context) {
+	defer w.dropPending()
+
+	delay := w.retryDelay
+	for {
+		alert, ok := w.next()
+		if !ok {
+			select {
+			case <-ctx.Done():
+				return
+			case <-w.wake:
+			}
+			continue
+		}
+
+		err := w.post(ctx, alert.body)
+		if err == nil {
+			delay = w.retryDelay
+			continue
+		}
+
+		var rejected *rejectedError
+		if errors.As(err, &rejected) {
+			// Retrying a request the endpoint refuses would block the queue forever
+			log.Printf("Dropping alert %q for webhook %s: %v", alert.title, w.host, err)
+			continue
+		}
+
+		w.requeue(alert)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("Failed to post alert %q to webhook %s, retrying in %s: %v", alert.title, w.host, delay, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(2*delay, maxRetryDelay)
+	}
+}
+
+// dropPending logs and drops the pending alerts
+func (w *Webhook) dropPending() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, alert := range w.pending {
+		log.Printf("Dropping alert %q for webhook %s, shutting down", alert.title, w.host)
+	}
+	w.pending = nil
+}
+
+// next removes and returns the oldest pending alert
+func (w *Webhook) next() (webhookAlert, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.pending) == 0 {
+		return webhookAlert{}, false
+	}
+	alert := w.pending[0]
+	w.pending = w.pending[1:]
+	return alert, true
+}
+
+// requeue puts an alert that failed to post back at the front of the queue
+func (w *Webhook) requeue(alert webhookAlert) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.pending = append([]webhookAlert{alert}, w.pending...)
+	w.dropOverflow()
+}
+
+// rejectedError is a client error response; the same request would fail again
+type rejectedError struct {
+	status string
+}
+
+func (e *rejectedError) Error() string {
+	return "rejected with " + e.status
+}
+
+// post sends one alert to the endpoint
+func (w *Webhook) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range w.headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode < 500 && resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests:
+		return &rejectedError{status: resp.Status}
+	default:
+		return fmt.Errorf("server responded with %s", resp.Status)
+	}
+}
+
+// render builds the request body of a notification in the webhook's format
+func (w *Webhook) render(notification Notification) ([]byte, error) {
+	switch w.format {
+	case config.WebhookFormatSlack:
+		return json.Marshal(map[string]any{"text": slackText(notification)})
+	case config.WebhookFormatTeams:
+		return json.Marshal(teamsCard(notification))
+	case config.WebhookFormatTemplate:
+		var body bytes.Buffer
+		if err := w.template.Execute(&body, newAlertPayload(notification)); err != nil {
+			return nil, err
+		}
+		return body.Bytes(), nil
+	default:
+		return json.Marshal(newAlertPayload(notification))
+	}
+}
+
+// newAlertPayload returns the data sent by json and template webhooks
+func newAlertPayload(notification Notification) alertPayload {
+	timestamp := notification.Time
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	return alertPayload{
+		Context:  notification.Context,
+		Title:    notification.Title,
+		Message:  notification.Body,
+		Severity: strings.ToLower(notification.Severity.String()),
+		Time:     timestamp,
+		Status:   notification.Status,
+		Changes:  notification.Changes,
+	}
+}
+
+// changeLines returns the messages of the most severe changes, most severe
+// first, and the number of changes left out
+func changeLines(changes []models.Change) ([]string, int) {
+	var lines []string
+	for _, severity := range []models.HealthStatus{models.HealthCritical, models.HealthWarning, models.HealthUnknown, models.HealthHealthy} {
+		for _, change := range changes {
+			if change.Severity == severity && len(lines) < maxAlertChanges {
+				lines = append(lines, change.Message)
+			}
+		}
+	}
+	return lines, len(changes) - len(lines)
+}
+
+// severityEmoji marks the severity of an alert in chat messages
+func severityEmoji(severity models.HealthStatus) string {
+	switch severity {
+	case models.HealthCritical:
+		return "🔴"
+	case models.HealthWarning:
+		return "🟡"
+	case models.HealthHealthy:
+		return "🟢"
+	default:
+		return "⚪"
+	}
+}
+
+// slackText formats a notification as a Slack message, e.g.
+// "🔴 *prod: Healthy → Critical*" followed by the body and the changes
+func slackText(notification Notification) string {
+	text := fmt.Sprintf("%s *%s*", severityEmoji(notification.Severity), notification.Title)
+	if notification.Body != "" {
+		text += "\n" + notification.Body
+	}
+
+	lines, omitted := changeLines(notification.Changes)
+	for _, line := range lines {
+		text += "\n• " + line
+	}
+	if omitted > 0 {
+		text += fmt.Sprintf("\n… and %d more changes", omitted)
+	}
+	return text
+}
+
+// teamsCard formats a notification as a Microsoft Teams message holding an Adaptive Card
+func teamsCard(notification Notification) map[string]any {
+	color := "Default"
+	switch notification.Severity {
+	case models.HealthCritical:
+		color = "Attention"
+	case models.HealthWarning:
+		color = "Warning"
+	case models.HealthHealthy:
+		color = "Good"
+	}
+
+	body := []map[string]any{{
+		"type":   "TextBlock",
+		"text":   notification.Title,
+		"weight": "Bolder",
+		"size":   "Medium",
+		"color":  color,
+		"wrap":   true,
+	}}
+	if notification.Body != "" {
+		body = append(body, map[string]any{"type": "TextBlock", "text": notification.Body, "wrap": true})
+	}
+
+	lines, omitted := changeLines(notification.Changes)
+	if omitted > 0 {
+		lines = append(lines, fmt.Sprintf("… and %d more changes", omitted))
+	}
+	if len(lines) > 0 {
+		body = append(body, map[string]any{
+			"type":     "TextBlock",
+			"text":     "- " + strings.Join(lines, "\n- "),
+			"wrap":     true,
+			"isSubtle": true,
+		})
+	}
+
+	return map[string]any{
+		"type": "message",
+		"attachments": []map[string]any{{
+			"contentType": "application/vnd.microsoft.card.adaptive",
+			"content": map[string]any{
+				"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+				"type":    "AdaptiveCard",
+				"version": "1.4",
+				"body":    body,
+			},
+		}},
+	}
+}
@@ -0,0 +1,354 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mattlqx/k8s-tray/internal/config"
+	"github.com/mattlqx/k8s-tray/pkg/models"
+)
+
+// webhookServer records alert bodies, failing the first failures requests with status
+type webhookServer struct {
+	mu       sync.Mutex
+	bodies   []string
+	headers  []http.Header
+	failures int
+	status   int
+}
+
+func (s *webhookServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failures > 0 {
+		s.failures--
+		w.WriteHeader(s.status)
+		return
+	}
+	s.bodies = append(s.bodies, string(body))
+	s.headers = append(s.headers, r.Header.Clone())
+}
+
+// waitFor polls until the server has accepted count alerts
+func (s *webhookServer) waitFor(t *testing.T, count int) []string {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for {
+		s.mu.Lock()
+		bodies := append([]string(nil), s.bodies...)
+		s.mu.Unlock()
+		if len(bodies) >= count {
+			return bodies
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected %d alerts, got %d", count, len(bodies))
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// testAlert is a critical notification with its status and changes
+func testAlert(title string) Notification {
+	return Notification{
+		Title:    title,
+		Body:     "Pods: 1 crash looping",
+		Severity: models.HealthCritical,
+		Context:  "prod",
+		Time:     time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		Status:   &models.ClusterStatus{ClusterName: "prod", HealthStatus: models.HealthCritical},
+		Changes: []models.Change{
+			{Kind: models.ChangePodAdded, Object: "default/worker", Severity: models.HealthHealthy, Message: "Pod default/worker was created (Ready)"},
+			{Kind: models.ChangePodState, Object: "default/api", Severity: models.HealthCritical, Message: "Pod default/api entered CrashLoopBackOff (was Ready)"},
+		},
+	}
+}
+
+// startWebhook creates a webhook posting to server and runs it until the test ends
+func startWebhook(t *testing.T, server *webhookServer, cfg config.Webhook) *Webhook {
+	t.Helper()
+	srv := httptest.NewServer(server)
+	t.Cleanup(srv.Close)
+
+	cfg.URL = srv.URL + "/hooks/secret-token"
+	webhook, err := NewWebhook(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create webhook: %v", err)
+	}
+	webhook.retryDelay = time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go webhook.Run(ctx)
+	return webhook
+}
+
+func TestWebhook_Formats(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      config.Webhook
+		expected string
+	}{
+		{
+			name:     "Slack",
+			cfg:      config.Webhook{Format: config.WebhookFormatSlack},
+			expected: `{"text":"🔴 *prod: Healthy → Critical*\nPods: 1 crash looping\n• Pod default/api entered CrashLoopBackOff (was Ready)\n• Pod default/worker was created (Ready)"}`,
+		},
+		{
+			name:     "Template",
+			cfg:      config.Webhook{Format: config.WebhookFormatTemplate, Template: `{"summary": {{json .Title}}, "severity": "{{.Severity}}", "changes": {{len .Changes}}}`},
+			expected: `{"summary": "prod: Healthy → Critical", "severity": "critical", "changes": 2}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &webhookServer{}
+			webhook := startWebhook(t, server, tt.cfg)
+
+			if err := webhook.Send(context.Background(), testAlert("prod: Healthy → Critical")); err != nil {
+				t.Fatalf("Failed to send alert: %v", err)
+			}
+			if body := server.waitFor(t, 1)[0]; body != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, body)
+			}
+		})
+	}
+}
+
+func TestWebhook_JSON(t *testing.T) {
+	server := &webhookServer{}
+	webhook := startWebhook(t, server, config.Webhook{
+		Format:  config.WebhookFormatJSON,
+		Headers: map[string]string{"Authorization": "Bearer token"},
+	})
+
+	if err := webhook.Send(context.Background(), testAlert("prod: Healthy → Critical")); err != nil {
+		t.Fatalf("Failed to send alert: %v", err)
+	}
+	body := server.waitFor(t, 1)[0]
+
+	var payload alertPayload
+	if err := json.Unmarshal([]byte(body), &payload); err != nil {
+		t.Fatalf("Failed to decode payload %s: %v", body, err)
+	}
+	if payload.Context != "prod" || payload.Severity != "critical" || payload.Message != "Pods: 1 crash looping" {
+		t.Errorf("Unexpected payload %+v", payload)
+	}
+	if payload.Status == nil || payload.Status.HealthStatus != models.HealthCritical {
+		t.Errorf("Expected the cluster status in the payload, got %+v", payload.Status)
+	}
+	if len(payload.Changes) != 2 {
+		t.Errorf("Expected 2 changes, got %+v", payload.Changes)
+	}
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	if auth := server.headers[0].Get("Authorization"); auth != "Bearer token" {
+		t.Errorf("Expected 'Bearer token', got '%s'", auth)
+	}
+	if contentType := server.headers[0].Get("Content-Type"); contentType != "application/json" {
+		t.Errorf("Expected 'application/json', got '%s'", contentType)
+	}
+}
+
+func TestWebhook_Teams(t *testing.T) {
+	webhook, err := NewWebhook(config.Webhook{URL: "https://example.webhook.office.com/x", Format: config.WebhookFormatTeams})
+	if err != nil {
+		t.Fatalf("Failed to create webhook: %v", err)
+	}
+
+	body, err := webhook.render(testAlert("prod: Healthy → Critical"))
+	if err != nil {
+		t.Fatalf("Failed to render alert: %v", err)
+	}
+
+	var message struct {
+		Type        string `json:"type"`
+		Attachments []struct {
+			ContentType string `json:"contentType"`
+			Content     struct {
+				Type string `json:"type"`
+				Body []struct {
+					Text  string `json:"text"`
+					Color string `json:"color"`
+				} `json:"body"`
+			} `json:"content"`
+		} `json:"attachments"`
+	}
+	if err := json.Unmarshal(body, &message); err != nil {
+		t.Fatalf("Failed to decode card %s: %v", body, err)
+	}
+
+	if message.Type != "message" || len(message.Attachments) != 1 {
+		t.Fatalf("Unexpected message %s", body)
+	}
+	card := message.Attachments[0]
+	if card.ContentType != "application/vnd.microsoft.card.adaptive" || card.Content.Type != "AdaptiveCard" {
+		t.Errorf("Unexpected attachment %s", body)
+	}
+	if len(card.Content.Body) != 3 || card.Content.Body[0].Text != "prod: Healthy → Critical" || card.Content.Body[0].Color != "Attention" {
+		t.Errorf("Unexpected card body %s", body)
+	}
+}
+
+func TestWebhook_MinSeverity(t *testing.T) {
+	tests := []struct {
+		name        string
+		minSeverity string
+		severity    models.HealthStatus
+		expected    bool
+	}{
+		{name: "Default skips recoveries", minSeverity: "", severity: models.HealthHealthy, expected: false},
+		{name: "Default sends warnings", minSeverity: "", severity: models.HealthWarning, expected: true},
+		{name: "Critical skips warnings", minSeverity: config.SeverityCritical, severity: models.HealthWarning, expected: false},
+		{name: "Critical sends critical", minSeverity: config.SeverityCritical, severity: models.HealthCritical, expected: true},
+		{name: "Healthy sends recoveries", minSeverity: config.SeverityHealthy, severity: models.HealthHealthy, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhook, err := NewWebhook(config.Webhook{URL: "https://hooks.example.com/alerts", MinSeverity: tt.minSeverity})
+			if err != nil {
+				t.Fatalf("Failed to create webhook: %v", err)
+			}
+
+			alert := testAlert("prod: alert")
+			alert.Severity = tt.severity
+			if err := webhook.Send(context.Background(), alert); err != nil {
+				t.Fatalf("Failed to send alert: %v", err)
+			}
+			if queued := len(webhook.pending) == 1; queued != tt.expected {
+				t.Errorf("Expected queued %v, got %v", tt.expected, queued)
+			}
+		})
+	}
+}
+
+func TestWebhook_Recovery(t *testing.T) {
+	webhook, err := NewWebhook(config.Webhook{
+		URL:         "https://hooks.example.com/alerts",
+		Format:      config.WebhookFormatTemplate,
+		Template:    "{{.Title}}",
+		MinSeverity: config.SeverityCritical,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create webhook: %v", err)
+	}
+	recovered := testAlert("prod: Recovered")
+	recovered.Severity = models.HealthHealthy
+
+	// Recoveries below the minimum severity are only sent after an alert
+	for _, notification := range []Notification{recovered, testAlert("prod: Healthy → Critical"), recovered, recovered} {
+		if err := webhook.Send(context.Background(), notification); err != nil {
+			t.Fatalf("Failed to send alert: %v", err)
+		}
+	}
+	var titles []string
+	for _, alert := range webhook.pending {
+		titles = append(titles, string(alert.body))
+	}
+	if strings.Join(titles, ",") != "prod: Healthy → Critical,prod: Recovered" {
+		t.Errorf("Expected the alert and one recovery, got %v", titles)
+	}
+}
+
+func TestWebhook_ConnectionAlerts(t *testing.T) {
+	lost := testAlert("prod: Unreachable")
+	lost.Connection = true
+
+	for _, enabled := range []bool{false, true} {
+		webhook, err := NewWebhook(config.Webhook{URL: "https://hooks.example.com/alerts", ConnectionAlerts: enabled})
+		if err != nil {
+			t.Fatalf("Failed to create webhook: %v", err)
+		}
+		if err := webhook.Send(context.Background(), lost); err != nil {
+			t.Fatalf("Failed to send alert: %v", err)
+		}
+		if queued := len(webhook.pending) == 1; queued != enabled {
+			t.Errorf("Expected queued %v with connection alerts %v, got %v", enabled, enabled, queued)
+		}
+	}
+}
+
+func TestWebhook_Retry(t *testing.T) {
+	// Unavailable for the first attempts, as while offline
+	server := &webhookServer{failures: 3, status: http.StatusServiceUnavailable}
+	webhook := startWebhook(t, server, config.Webhook{Format: config.WebhookFormatTemplate, Template: "{{.Title}}"})
+
+	for _, title := range []string{"first", "second", "third"} {
+		if err := webhook.Send(context.Background(), testAlert(title)); err != nil {
+			t.Fatalf("Failed to send alert: %v", err)
+		}
+	}
+
+	bodies := server.waitFor(t, 3)
+	if strings.Join(bodies, ",") != "first,second,third" {
+		t.Errorf("Expected alerts in order, got %v", bodies)
+	}
+}
+
+func TestWebhook_Rejected(t *testing.T) {
+	server := &webhookServer{failures: 1, status: http.StatusNotFound}
+	webhook := startWebhook(t, server, config.Webhook{Format: config.WebhookFormatTemplate, Template: "{{.Title}}"})
+
+	for _, title := range []string{"first", "second"} {
+		if err := webhook.Send(context.Background(), testAlert(title)); err != nil {
+			t.Fatalf("Failed to send alert: %v", err)
+		}
+	}
+
+	// The rejected alert is dropped rather than retried
+	bodies := server.waitFor(t, 1)
+	if len(bodies) != 1 || bodies[0] != "second" {
+		t.Errorf("Expected only the second alert, got %v", bodies)
+	}
+}
+
+func TestWebhook_QueueLimit(t *testing.T) {
+	webhook, err := NewWebhook(config.Webhook{URL: "https://hooks.example.com/alerts", Format: config.WebhookFormatTemplate, Template: "{{.Title}}"})
+	if err != nil {
+		t.Fatalf("Failed to create webhook: %v", err)
+	}
+
+	for i := 0; i <= maxQueuedAlerts; i++ {
+		if err := webhook.Send(context.Background(), testAlert(time.Duration(i).String())); err != nil {
+			t.Fatalf("Failed to send alert: %v", err)
+		}
+	}
+
+	if len(webhook.pending) != maxQueuedAlerts {
+		t.Fatalf("Expected %d pending alerts, got %d", maxQueuedAlerts, len(webhook.pending))
+	}
+	if oldest := string(webhook.pending[0].body); oldest != "1ns" {
+		t.Errorf("Expected the oldest alert to be dropped, got '%s' first", oldest)
+	}
+}
+
+func TestNewWebhook_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.Webhook
+	}{
+		{name: "No URL", cfg: config.Webhook{}},
+		{name: "Unsupported scheme", cfg: config.Webhook{URL: "ftp://hooks.example.com"}},
+		{name: "Missing template", cfg: config.Webhook{URL: "https://hooks.example.com", Format: config.WebhookFormatTemplate}},
+		{name: "Broken template", cfg: config.Webhook{URL: "https://hooks.example.com", Format: config.WebhookFormatTemplate, Template: "{{.Title"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewWebhook(tt.cfg); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package tray
+
+import (
+	"math/rand"
+	"time"
+)
+
+// maxRetryDelay caps the delay between refreshes of an unreachable cluster
+const maxRetryDelay = 5 * time.Minute
+
+// retryJitter is the fraction by which retry delays are randomly spread, so
+// that clients losing the same cluster do not retry in lockstep
+const retryJitter = 0.2
+
+// backoff computes refresh delays that grow exponentially with consecutive
+// failures and return to the poll interval after a success
+type backoff struct {
+	failures int
+	random   func() float64 // Returns a value in [0, 1)
+}
+
+// newBackoff creates a backoff with randomized jitter
+func newBackoff() *backoff {
+	// #nosec G404 -- jitter does not need a cryptographically secure source
+	return &backoff{random: rand.Float64}
+}
+
+// next records a failure and returns the delay before the next attempt:
+// interval doubled for every consecutive failure after the first, capped at
+// maxRetryDelay and spread by ±retryJitter
+func (b *backoff) next(interval time.Duration) time.Duration {
+	b.failures++
+
+	delay := interval
+	for i := 1; i < b.failures && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+
+	jitter := (b.random()*2 - 1) * retryJitter
+	return time.Duration(float64(delay) * (1 + jitter))
+}
+
+// reset records a success
+func (b *backoff) reset() {
+	b.failures = 0
+}
+
+// retrying reports whether the last attempt failed
+func (b *backoff) retrying() bool {
+	return b.failures > 0
+}
@@ -0,0 +1,69 @@
+package tray
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	jitter := 0.5 // No jitter
+	b := &backoff{random: func() float64 { return jitter }}
+
+	expected := []time.Duration{
+		15 * time.Second,
+		30 * time.Second,
+		time.Minute,
+		2 * time.Minute,
+		4 * time.Minute,
+		maxRetryDelay,
+		maxRetryDelay,
+	}
+	for i, want := range expected {
+		if got := b.next(15 * time.Second); got != want {
+			t.Errorf("Failure %d: expected %v, got %v", i+1, want, got)
+		}
+	}
+	if !b.retrying() {
+		t.Error("Expected backoff to be retrying after failures")
+	}
+
+	// Success snaps back to the poll interval
+	b.reset()
+	if b.retrying() {
+		t.Error("Expected backoff not to be retrying after reset")
+	}
+	if got := b.next(15 * time.Second); got != 15*time.Second {
+		t.Errorf("Expected 15s after reset, got %v", got)
+	}
+
+	// Jitter spreads the delay by up to 20% either way
+	jitter = 0
+	if got := b.next(15 * time.Second); got != 24*time.Second {
+		t.Errorf("Expected 24s with minimum jitter, got %v", got)
+	}
+}
+
+func TestFormatDataAge(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		lastRefresh time.Time
+		nextRetry   time.Time
+		expected    string
+	}{
+		{name: "Never refreshed", expected: "Data Age: Unknown"},
+		{name: "Fresh", lastRefresh: now.Add(-5 * time.Second), expected: "Data Age: 5s ago"},
+		{name: "Retrying", lastRefresh: now.Add(-2 * time.Minute), nextRetry: now.Add(29500 * time.Millisecond), expected: "Data Age: 2m ago · Retrying in 30s"},
+		{name: "Retrying before first refresh", nextRetry: now.Add(8 * time.Second), expected: "Data Age: Unknown · Retrying in 8s"},
+		{name: "Retry due", lastRefresh: now.Add(-time.Minute), nextRetry: now.Add(-time.Second), expected: "Data Age: 1m ago · Retrying in 0s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := formatDataAge(tt.lastRefresh, tt.nextRetry, now); result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package tray
+
+import (
+	"fmt"
+	"time"
+
+	"fyne.io/systray"
+	"github.com/mattlqx/k8s-tray/pkg/models"
+)
+
+// maxRecentChanges limits how many changes the Recent Changes submenu keeps
+const maxRecentChanges = 20
+
+// recordChanges adds the changes of a refresh to the recent changes, dropping
+// the oldest; m.statusMu must be held
+func (m *Manager) recordChanges(changes []models.Change) {
+	m.recentChanges = append(m.recentChanges, changes...)
+	if excess := len(m.recentChanges) - maxRecentChanges; excess > 0 {
+		m.recentChanges = append([]models.Change(nil), m.recentChanges[excess:]...)
+	}
+}
+
+// updateChangesSubmenu lists the recent changes, newest first; m.statusMu must be held
+func (m *Manager) updateChangesSubmenu() {
+	m.clearChangesSubmenu()
+
+	if len(m.recentChanges) == 0 {
+		m.changesMenu.Hide()
+		return
+	}
+
+	m.changesMenu.SetTitle(fmt.Sprintf("Recent Changes: %d", len(m.recentChanges)))
+	m.changesMenu.Show()
+
+	for i := len(m.recentChanges) - 1; i >= 0; i-- {
+		change := m.recentChanges[i]
+		tooltip := fmt.Sprintf("%s\nAt: %s", change.Message, change.Time.Format(time.RFC1123))
+		if change.From != "" || change.To != "" {
+			tooltip += fmt.Sprintf("\nFrom: %s\nTo: %s", change.From, change.To)
+		}
+
+		item := m.changesMenu.AddSubMenuItem(formatChangeTitle(change), tooltip)
+		item.Disable() // Informational only
+		m.changeItems[fmt.Sprint(i)] = item
+	}
+}
+
+// clearChangesSubmenu clears all existing change submenu items
+func (m *Manager) clearChangesSubmenu() {
+	removeMenuItems(m.changeItems)
+	m.changeItems = make(map[string]*systray.MenuItem)
+}
+
+// formatChangeTitle formats a change entry, e.g. "🔴 14:02:11 Pod default/api entered CrashLoopBackOff (was Ready)"
+func formatChangeTitle(change models.Change) string {
+	return fmt.Sprintf("%s %s %s", healthBadge(change.Severity), change.Time.Local().Format("15:04:05"), change.Message)
+}
@@ -0,0 +1,43 @@
+package tray
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mattlqx/k8s-tray/pkg/models"
+)
+
+func TestRecordChanges(t *testing.T) {
+	m := &Manager{}
+
+	var changes []models.Change
+	for i := 0; i < maxRecentChanges+5; i++ {
+		changes = append(changes, models.Change{Object: fmt.Sprint(i)})
+	}
+	m.recordChanges(changes[:3])
+	m.recordChanges(changes[3:])
+
+	if len(m.recentChanges) != maxRecentChanges {
+		t.Fatalf("Expected %d changes, got %d", maxRecentChanges, len(m.recentChanges))
+	}
+	if oldest := m.recentChanges[0].Object; oldest != "5" {
+		t.Errorf("Expected the oldest kept change to be '5', got '%s'", oldest)
+	}
+	if newest := m.recentChanges[maxRecentChanges-1].Object; newest != fmt.Sprint(maxRecentChanges+4) {
+		t.Errorf("Expected the newest change to be kept, got '%s'", newest)
+	}
+}
+
+func TestFormatChangeTitle(t *testing.T) {
+	change := models.Change{
+		Time:     time.Date(2024, 5, 1, 14, 2, 11, 0, time.Local),
+		Severity: models.HealthCritical,
+		Message:  "Pod default/api entered CrashLoopBackOff (was Ready)",
+	}
+
+	expected := "🔴 14:02:11 Pod default/api entered CrashLoopBackOff (was Ready)"
+	if result := formatChangeTitle(change); result != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, result)
+	}
+}
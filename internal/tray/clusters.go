@@ -0,0 +1,415 @@
+package tray
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"fyne.io/systray"
+	"github.com/mattlqx/k8s-tray/internal/kubernetes"
+	"github.com/mattlqx/k8s-tray/pkg/models"
+)
+
+// clusterMonitor tracks one of the monitored contexts and its menu section
+type clusterMonitor struct {
+	name string
+
+	// Menu items
+	item        *systray.MenuItem
+	statusItem  *systray.MenuItem
+	nodesItem   *systray.MenuItem
+	updatedItem *systray.MenuItem
+	loginItem   *systray.MenuItem
+	switchItem  *systray.MenuItem
+
+	// Latest result, guarded by Manager.clustersMu
+	primary    bool // Shown from the primary context's status instead of its own loop
+	status     *models.ClusterStatus
+	err        error
+	state      models.ConnectionState
+	lastUpdate time.Time
+}
+
+// health returns the monitor's current health; the health of unreachable
+// clusters is unknown, their connection state is tracked separately
+func (c *clusterMonitor) health() models.HealthStatus {
+	switch {
+	case c.err != nil:
+		return models.HealthUnknown
+	case c.status != nil:
+		return c.status.HealthStatus
+	default:
+		return models.HealthUnknown
+	}
+}
+
+// hasData reports whether the monitor has completed at least one refresh
+func (c *clusterMonitor) hasData() bool {
+	return c.status != nil || c.err != nil
+}
+
+// buildClusterMenu adds a section with one item per monitored context
+func (m *Manager) buildClusterMenu() {
+	seen := make(map[string]bool)
+	for _, name := range m.config.MonitoredContexts {
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		if len(m.clusterMonitors) == 0 {
+			systray.AddSeparator()
+			m.clustersLabel = systray.AddMenuItem("Monitored Clusters", "Health of all monitored contexts")
+			m.clustersLabel.Disable()
+		}
+
+		mon := &clusterMonitor{name: name}
+		mon.item = systray.AddMenuItem(formatClusterTitle(name, models.HealthUnknown, nil, nil), fmt.Sprintf("Context %s", name))
+		// Keep enabled to allow submenu access on macOS
+		mon.statusItem = mon.item.AddSubMenuItem("Status: Connecting...", "Cluster health")
+		mon.statusItem.Disable()
+		mon.nodesItem = mon.item.AddSubMenuItem("Nodes: Unknown", "Node readiness")
+		mon.nodesItem.Disable()
+		mon.updatedItem = mon.item.AddSubMenuItem("Updated: Never", "Time since last successful refresh")
+		mon.updatedItem.Disable()
+		mon.loginItem = mon.item.AddSubMenuItem("🔑 Log In", "Run the context's login command and reconnect")
+		mon.loginItem.Hide()
+		mon.switchItem = mon.item.AddSubMenuItem("Switch to this context", fmt.Sprintf("Show full details for %s", name))
+
+		m.clusterMonitors = append(m.clusterMonitors, mon)
+	}
+}
+
+// handleClusterActions handles clicks on the monitored cluster items
+func (m *Manager) handleClusterActions(ctx context.Context) {
+	for _, mon := range m.clusterMonitors {
+		go func(mon *clusterMonitor) {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-mon.item.ClickedCh:
+					// Cluster details are shown in the submenu
+				case <-mon.switchItem.ClickedCh:
+					m.switchContext(mon.name)
+				case <-mon.loginItem.ClickedCh:
+					go m.logIn(ctx, mon.name, mon.loginItem)
+				}
+			}
+		}(mon)
+	}
+}
+
+// startClusterMonitors starts a monitoring loop with its own client for every
+// monitored context except the primary one, which is already being monitored
+func (m *Manager) startClusterMonitors(parent context.Context) {
+	if len(m.clusterMonitors) == 0 {
+		return
+	}
+
+	// Stop the loops of a previous start before replacing them
+	ctx, cancel := context.WithCancel(parent)
+	m.clustersMu.Lock()
+	if m.clustersCancel != nil {
+		m.clustersCancel()
+	}
+	m.clustersCancel = cancel
+	m.clustersMu.Unlock()
+
+	primary, err := m.k8sClient.GetCurrentContext()
+	if err != nil {
+		log.Printf("Failed to get current context: %v", err)
+	}
+
+	for _, mon := range m.clusterMonitors {
+		m.clustersMu.Lock()
+		mon.primary = mon.name == primary
+		mon.status, mon.err, mon.lastUpdate = nil, nil, time.Time{}
+		m.clustersMu.Unlock()
+
+		if mon.primary {
+			// Mirror the primary context's status if it is already known
+			m.statusMu.Lock()
+			if m.currentStatus != nil {
+				m.updatePrimaryCluster(m.currentStatus, nil)
+			}
+			m.statusMu.Unlock()
+			continue
+		}
+
+		source, err := m.newSource(m.config, mon.name)
+		if err != nil {
+			log.Printf("Failed to create client for monitored context %s: %v", mon.name, err)
+			m.setClusterResult(mon, nil, err)
+			continue
+		}
+
+		go m.monitorCluster(ctx, mon, source)
+	}
+}
+
+// stopClusterMonitors stops all monitoring loops of secondary contexts
+func (m *Manager) stopClusterMonitors() {
+	m.clustersMu.Lock()
+	defer m.clustersMu.Unlock()
+	if m.clustersCancel != nil {
+		m.clustersCancel()
+		m.clustersCancel = nil
+	}
+}
+
+// restartClusterMonitors restarts the monitoring loops of secondary contexts
+// with fresh clients
+func (m *Manager) restartClusterMonitors() {
+	m.switchMu.Lock()
+	defer m.switchMu.Unlock()
+	m.stopClusterMonitors()
+	m.startClusterMonitors(m.mainCtx)
+}
+
+// monitorCluster polls a monitored context at the configured poll interval,
+// backing off while the context keeps failing
+func (m *Manager) monitorCluster(ctx context.Context, mon *clusterMonitor, source ClusterSource) {
+	retry := newBackoff()
+	for {
+		status, err := source.GetClusterStatus(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		m.setClusterResult(mon, status, err)
+
+		delay := m.config.PollInterval
+		if err != nil {
+			delay = retry.next(delay)
+			log.Printf("Failed to get status for monitored context %s, retrying in %s: %v", mon.name, delay.Round(time.Second), err)
+		} else {
+			retry.reset()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+// updatePrimaryCluster mirrors the primary context's result into its monitored cluster section
+func (m *Manager) updatePrimaryCluster(status *models.ClusterStatus, err error) {
+	for _, mon := range m.clusterMonitors {
+		m.clustersMu.Lock()
+		primary := mon.primary
+		m.clustersMu.Unlock()
+
+		if primary {
+			m.setClusterResult(mon, status, err)
+		}
+	}
+}
+
+// setClusterResult records a monitor's latest result and refreshes its menu
+// section, its context badge and the aggregated icon
+func (m *Manager) setClusterResult(mon *clusterMonitor, status *models.ClusterStatus, err error) {
+	m.clustersMu.Lock()
+	if err != nil {
+		mon.err = err
+	} else {
+		mon.status, mon.err, mon.lastUpdate = status, nil, time.Now()
+	}
+	mon.state = kubernetes.ClassifyError(mon.err)
+	health := mon.health()
+	status, err, state, lastUpdate := mon.status, mon.err, mon.state, mon.lastUpdate
+	m.clustersMu.Unlock()
+
+	mon.item.SetTitle(formatClusterTitle(mon.name, health, status, err))
+	if err != nil {
+		mon.statusItem.SetTitle(formatConnectionStatus(state))
+		mon.statusItem.SetTooltip(err.Error())
+	} else {
+		mon.statusItem.SetTitle(fmt.Sprintf("Status: %s (%s)", health, status.ServerVersion))
+		mon.statusItem.SetTooltip("Cluster health")
+	}
+	if state == models.ConnectionLoginRequired {
+		setLoginItem(mon.loginItem, m.config.ContextSettings(mon.name).LoginCommand, mon.name)
+		mon.loginItem.Show()
+	} else {
+		mon.loginItem.Hide()
+	}
+	if status != nil && status.NodeStatus != nil {
+		mon.nodesItem.SetTitle(formatNodeSummary(status.NodeStatus))
+	}
+	if !lastUpdate.IsZero() {
+		mon.updatedItem.SetTitle(fmt.Sprintf("Updated: %s", formatAge(time.Since(lastUpdate))))
+	}
+
+	m.clustersMu.Lock()
+	if item, exists := m.contextItems[mon.name]; exists {
+		item.SetTitle(m.contextTitle(mon.name))
+	}
+	m.clustersMu.Unlock()
+
+	m.refreshIcon()
+}
+
+// updateClusterAges refreshes the time since each monitored cluster's last update
+func (m *Manager) updateClusterAges() {
+	m.clustersMu.Lock()
+	defer m.clustersMu.Unlock()
+
+	for _, mon := range m.clusterMonitors {
+		if !mon.lastUpdate.IsZero() {
+			mon.updatedItem.SetTitle(fmt.Sprintf("Updated: %s", formatAge(time.Since(mon.lastUpdate))))
+		}
+	}
+}
+
+// aggregateHealth returns the worst health across the primary context and all monitored contexts
+func (m *Manager) aggregateHealth() models.HealthStatus {
+	m.clustersMu.Lock()
+	defer m.clustersMu.Unlock()
+
+	healths := []models.HealthStatus{m.currentHealth}
+	for _, mon := range m.clusterMonitors {
+		// Clusters still connecting should not hide the state of the others
+		if !mon.primary && mon.hasData() {
+			healths = append(healths, mon.health())
+		}
+	}
+	return combineHealth(healths)
+}
+
+// connectionProblem returns the connection state of the current context, or of
+// the first unreachable monitored context when the current one is reachable
+func (m *Manager) connectionProblem() models.ConnectionState {
+	m.clustersMu.Lock()
+	defer m.clustersMu.Unlock()
+
+	if m.connectionState != models.ConnectionOK {
+		return m.connectionState
+	}
+	for _, mon := range m.clusterMonitors {
+		if !mon.primary && mon.state != models.ConnectionOK {
+			return mon.state
+		}
+	}
+	return models.ConnectionOK
+}
+
+// mutedIcon reports whether the icon shows the muted overlay: alerts about
+// every cluster with a problem are muted, those about the current context by
+// a snooze, acknowledgement or maintenance window and those about the other
+// monitored contexts by their own maintenance windows
+func (m *Manager) mutedIcon(now time.Time) bool {
+	primaryMuted := m.currentMute().reason != muteNone
+
+	m.clustersMu.Lock()
+	defer m.clustersMu.Unlock()
+
+	if hasProblem(m.currentHealth, m.connectionState) && !primaryMuted {
+		return false
+	}
+	othersMuted := false
+	for _, mon := range m.clusterMonitors {
+		if mon.primary || !mon.hasData() || !hasProblem(mon.health(), mon.state) {
+			continue
+		}
+		if _, ok := activeMaintenance(m.config.ContextSettings(mon.name).MaintenanceWindows, now); !ok {
+			return false
+		}
+		othersMuted = true
+	}
+	return primaryMuted || othersMuted
+}
+
+// hasProblem reports whether a cluster cannot be reached or is not healthy
+func hasProblem(health models.HealthStatus, state models.ConnectionState) bool {
+	return state != models.ConnectionOK || (health != models.HealthHealthy && health != models.HealthUnknown)
+}
+
+// combineHealth combines cluster health values, ignoring unknown ones unless
+// nothing else is known
+func combineHealth(healths []models.HealthStatus) models.HealthStatus {
+	var known []models.HealthStatus
+	for _, health := range healths {
+		if health != models.HealthUnknown {
+			known = append(known, health)
+		}
+	}
+	if len(known) == 0 {
+		return models.HealthUnknown
+	}
+	return models.WorstHealth(known...)
+}
+
+// clusterTooltip returns tooltip lines with the health of the other monitored clusters
+func (m *Manager) clusterTooltip() string {
+	m.clustersMu.Lock()
+	defer m.clustersMu.Unlock()
+
+	var tooltip string
+	for _, mon := range m.clusterMonitors {
+		switch {
+		case mon.primary || !mon.hasData():
+		case mon.err != nil:
+			tooltip += fmt.Sprintf("\n%s %s: %s", healthBadge(mon.health()), mon.name, mon.state)
+		default:
+			tooltip += fmt.Sprintf("\n%s %s: %s", healthBadge(mon.health()), mon.name, mon.health())
+		}
+	}
+	return tooltip
+}
+
+// contextTitle returns the context submenu title, with a health badge for
+// monitored contexts and the source file when several kubeconfigs are merged;
+// m.clustersMu must be held
+func (m *Manager) contextTitle(name string) string {
+	title := formatContextTitle(name, m.contextSources[name], m.multipleKubeconfigs)
+	for _, mon := range m.clusterMonitors {
+		if mon.name == name {
+			return fmt.Sprintf("%s %s", healthBadge(mon.health()), title)
+		}
+	}
+	return title
+}
+
+// formatClusterTitle formats a monitored cluster entry, e.g. "🟡 prod-eu: 120 pods (3 not ready)"
+func formatClusterTitle(name string, health models.HealthStatus, status *models.ClusterStatus, err error) string {
+	badge := healthBadge(health)
+	switch {
+	case err != nil:
+		return fmt.Sprintf("%s %s: %s", badge, name, strings.ToLower(kubernetes.ClassifyError(err).String()))
+	case status == nil || status.PodStatus == nil:
+		return fmt.Sprintf("%s %s: connecting...", badge, name)
+	}
+
+	pods := status.PodStatus
+	title := fmt.Sprintf("%s %s: %d pods", badge, name, pods.Total)
+
+	var problems []string
+	if notReady := pods.RunningNotReady + pods.Pending; notReady > 0 {
+		problems = append(problems, fmt.Sprintf("%d not ready", notReady))
+	}
+	if failed := pods.Failed + pods.CrashLoop + pods.ImagePullError + pods.ConfigError + pods.OOMKilled; failed > 0 {
+		problems = append(problems, fmt.Sprintf("%d failing", failed))
+	}
+	if len(problems) > 0 {
+		title += fmt.Sprintf(" (%s)", strings.Join(problems, ", "))
+	}
+	return title
+}
+
+// healthBadge returns the colored badge for a health status
+func healthBadge(health models.HealthStatus) string {
+	switch health {
+	case models.HealthHealthy:
+		return "🟢"
+	case models.HealthWarning:
+		return "🟡"
+	case models.HealthCritical:
+		return "🔴"
+	default:
+		return "⚪"
+	}
+}
@@ -0,0 +1,164 @@
+package tray
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mattlqx/k8s-tray/internal/config"
+	"github.com/mattlqx/k8s-tray/pkg/models"
+)
+
+func TestFormatClusterTitle(t *testing.T) {
+	tests := []struct {
+		name     string
+		health   models.HealthStatus
+		status   *models.ClusterStatus
+		err      error
+		expected string
+	}{
+		{
+			name:     "Connecting",
+			health:   models.HealthUnknown,
+			expected: "⚪ prod-eu: connecting...",
+		},
+		{
+			name:     "Unreachable",
+			health:   models.HealthUnknown,
+			err:      errors.New("connection refused"),
+			expected: "⚪ prod-eu: unreachable",
+		},
+		{
+			name:     "Login required",
+			health:   models.HealthUnknown,
+			err:      errors.New("getting credentials: exec: executable aws failed"),
+			expected: "⚪ prod-eu: login required",
+		},
+		{
+			name:     "Healthy",
+			health:   models.HealthHealthy,
+			status:   &models.ClusterStatus{PodStatus: &models.PodStatus{Total: 120, RunningReady: 120}},
+			expected: "🟢 prod-eu: 120 pods",
+		},
+		{
+			name:   "Problems",
+			health: models.HealthCritical,
+			status: &models.ClusterStatus{PodStatus: &models.PodStatus{
+				Total: 120, RunningReady: 115, RunningNotReady: 2, Pending: 1, CrashLoop: 2,
+			}},
+			expected: "🔴 prod-eu: 120 pods (3 not ready, 2 failing)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := formatClusterTitle("prod-eu", tt.health, tt.status, tt.err)
+			if result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCombineHealth(t *testing.T) {
+	tests := []struct {
+		name     string
+		healths  []models.HealthStatus
+		expected models.HealthStatus
+	}{
+		{"Nothing known", []models.HealthStatus{models.HealthUnknown, models.HealthUnknown}, models.HealthUnknown},
+		{"Unknown ignored", []models.HealthStatus{models.HealthUnknown, models.HealthHealthy}, models.HealthHealthy},
+		{"Worst wins", []models.HealthStatus{models.HealthHealthy, models.HealthCritical, models.HealthWarning}, models.HealthCritical},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := combineHealth(tt.healths)
+			if result != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestAggregateHealth(t *testing.T) {
+	m := &Manager{
+		config:        &config.Config{},
+		currentHealth: models.HealthHealthy,
+		clusterMonitors: []*clusterMonitor{
+			// The primary's section mirrors currentHealth and is not counted twice
+			{name: "prod-us", primary: true, err: errors.New("stale")},
+			{name: "prod-eu", status: &models.ClusterStatus{HealthStatus: models.HealthWarning}},
+			{name: "staging"}, // Still connecting
+		},
+	}
+
+	if result := m.aggregateHealth(); result != models.HealthWarning {
+		t.Errorf("Expected %s, got %s", models.HealthWarning, result)
+	}
+
+	// Connection problems are reported separately and do not raise the health
+	m.clusterMonitors[2].err = errors.New("connection refused")
+	m.clusterMonitors[2].state = models.ConnectionUnreachable
+	if result := m.aggregateHealth(); result != models.HealthWarning {
+		t.Errorf("Expected %s after a cluster became unreachable, got %s", models.HealthWarning, result)
+	}
+	if result := m.connectionProblem(); result != models.ConnectionUnreachable {
+		t.Errorf("Expected '%s', got '%s'", models.ConnectionUnreachable, result)
+	}
+
+	// The current context's own connection problem takes precedence
+	m.setPrimaryState(models.HealthUnknown, models.ConnectionUnauthorized)
+	if result := m.connectionProblem(); result != models.ConnectionUnauthorized {
+		t.Errorf("Expected '%s', got '%s'", models.ConnectionUnauthorized, result)
+	}
+}
+
+func TestContextTitle(t *testing.T) {
+	m := &Manager{
+		config: &config.Config{},
+		clusterMonitors: []*clusterMonitor{
+			{name: "prod-eu", status: &models.ClusterStatus{HealthStatus: models.HealthHealthy}},
+		},
+	}
+
+	if result := m.contextTitle("prod-eu"); result != "🟢 prod-eu" {
+		t.Errorf("Expected '🟢 prod-eu', got '%s'", result)
+	}
+	if result := m.contextTitle("dev"); result != "dev" {
+		t.Errorf("Expected 'dev', got '%s'", result)
+	}
+}
+
+func TestMutedIcon(t *testing.T) {
+	now := time.Date(2024, 6, 8, 12, 0, 0, 0, time.Local)
+	m := &Manager{
+		config: &config.Config{Contexts: map[string]config.ContextSettings{
+			"staging": {MaintenanceWindows: []config.MaintenanceWindow{{Schedule: "0 11 * * *", Duration: 2 * time.Hour}}},
+		}},
+		currentHealth: models.HealthHealthy,
+		clusterMonitors: []*clusterMonitor{
+			{name: "prod-eu", status: &models.ClusterStatus{HealthStatus: models.HealthHealthy}},
+			{name: "staging", status: &models.ClusterStatus{HealthStatus: models.HealthCritical}},
+		},
+	}
+
+	// Staging is critical during its own maintenance window
+	if !m.mutedIcon(now) {
+		t.Error("Expected the icon to be muted while the only problem is in maintenance")
+	}
+	if m.mutedIcon(now.Add(2 * time.Hour)) {
+		t.Error("Expected the icon not to be muted after the maintenance window")
+	}
+
+	// Snoozing the current context does not mute problems of the others
+	m.mute.snoozedUntil = time.Now().Add(time.Hour)
+	m.clusterMonitors[0].status.HealthStatus = models.HealthWarning
+	if m.mutedIcon(now) {
+		t.Error("Expected the icon not to be muted while another cluster has a problem")
+	}
+	m.clusterMonitors[0].status.HealthStatus = models.HealthHealthy
+	if !m.mutedIcon(now) {
+		t.Error("Expected the icon to be muted while the current context is snoozed")
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"image/color"
 	"image/png"
 	"runtime"
+
+	"github.com/mattlqx/k8s-tray/pkg/models"
 )
 
 // createSimpleIcon creates a simple colored square icon
@@ -219,3 +221,112 @@ func getRedIcon() []byte {
 func getGrayIcon() []byte {
 	return createSimpleIcon(128, 128, 128) // Gray
 }
+
+// createRingIcon creates a hollow ring icon, used when the cluster cannot be
+// reached, crossed by a dark slash while alerts are muted
+func createRingIcon(r, g, b uint8, muted bool) []byte {
+	const size = 16
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+
+	// Draw a ring around the center, leaving the background transparent
+	centerX, centerY := size/2, size/2
+	outer, inner := size/4+1, size/4-1
+	slashRadius := outer + 1
+
+	ringColor := color.RGBA{r, g, b, 255}
+	slashColor := color.RGBA{48, 48, 48, 255}
+
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			dx := x - centerX
+			dy := y - centerY
+			d := dx*dx + dy*dy
+			onSlash := x+y >= centerX+centerY-1 && x+y <= centerX+centerY+1
+			switch {
+			case muted && onSlash && d <= slashRadius*slashRadius:
+				img.Set(x, y, slashColor)
+			case d <= outer*outer && d > inner*inner:
+				img.Set(x, y, ringColor)
+			}
+		}
+	}
+
+	if runtime.GOOS == "windows" {
+		return createICOFromImage(img)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return []byte{}
+	}
+	return buf.Bytes()
+}
+
+// getConnectionIcon returns the icon for a connection problem: gray for network
+// problems, orange for credential problems, purple for TLS and red for server
+// errors, with the muted overlay while alerts are muted
+func getConnectionIcon(state models.ConnectionState, muted bool) []byte {
+	switch state {
+	case models.ConnectionUnauthorized, models.ConnectionLoginRequired, models.ConnectionForbidden:
+		return createRingIcon(255, 140, 0, muted) // Orange
+	case models.ConnectionTLSError:
+		return createRingIcon(160, 32, 240, muted) // Purple
+	case models.ConnectionServerError:
+		return createRingIcon(255, 0, 0, muted) // Red
+	default:
+		return createRingIcon(128, 128, 128, muted) // Gray
+	}
+}
+
+// createMutedIcon creates a circle icon crossed by a dark slash, shown while alerts are muted
+func createMutedIcon(r, g, b uint8) []byte {
+	const size = 16
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+
+	// Draw the circle, then a slash from top right to bottom left through its center
+	centerX, centerY := size/2, size/2
+	radius := size / 4
+	slashRadius := radius + 2
+
+	iconColor := color.RGBA{r, g, b, 255}
+	slashColor := color.RGBA{48, 48, 48, 255}
+
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			dx := x - centerX
+			dy := y - centerY
+			d := dx*dx + dy*dy
+			onSlash := x+y >= centerX+centerY-1 && x+y <= centerX+centerY+1
+			switch {
+			case onSlash && d <= slashRadius*slashRadius:
+				img.Set(x, y, slashColor)
+			case d <= radius*radius:
+				img.Set(x, y, iconColor)
+			}
+		}
+	}
+
+	if runtime.GOOS == "windows" {
+		return createICOFromImage(img)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return []byte{}
+	}
+	return buf.Bytes()
+}
+
+// getMutedIcon returns the icon for a health status while alerts are muted
+func getMutedIcon(health models.HealthStatus) []byte {
+	switch health {
+	case models.HealthHealthy:
+		return createMutedIcon(0, 255, 0)
+	case models.HealthWarning:
+		return createMutedIcon(255, 255, 0)
+	case models.HealthCritical:
+		return createMutedIcon(255, 0, 0)
+	default:
+		return createMutedIcon(128, 128, 128)
+	}
+}
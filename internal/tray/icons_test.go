@@ -1,8 +1,11 @@
 package tray
 
 import (
+	"bytes"
 	"runtime"
 	"testing"
+
+	"github.com/mattlqx/k8s-tray/pkg/models"
 )
 
 func TestCreateSimpleIcon(t *testing.T) {
@@ -74,3 +77,32 @@ func TestGetIconFunctions(t *testing.T) {
 		}
 	}
 }
+
+func TestGetConnectionIcon(t *testing.T) {
+	for state := models.ConnectionUnreachable; state <= models.ConnectionFailed; state++ {
+		if len(getConnectionIcon(state, false)) == 0 || len(getConnectionIcon(state, true)) == 0 {
+			t.Errorf("%s icon should not be empty", state)
+		}
+	}
+
+	// Credential problems are distinguishable from network problems
+	if bytes.Equal(getConnectionIcon(models.ConnectionUnauthorized, false), getConnectionIcon(models.ConnectionUnreachable, false)) {
+		t.Error("Unauthorized and unreachable icons should differ")
+	}
+	if bytes.Equal(getConnectionIcon(models.ConnectionUnreachable, true), getConnectionIcon(models.ConnectionUnreachable, false)) {
+		t.Error("Muted and unmuted connection icons should differ")
+	}
+}
+
+func TestGetMutedIcon(t *testing.T) {
+	for _, health := range []models.HealthStatus{models.HealthUnknown, models.HealthHealthy, models.HealthWarning, models.HealthCritical} {
+		if len(getMutedIcon(health)) == 0 {
+			t.Errorf("Muted %s icon should not be empty", health)
+		}
+	}
+
+	// The overlay distinguishes muted alerts from the plain health icon
+	if bytes.Equal(getMutedIcon(models.HealthCritical), getRedIcon()) {
+		t.Error("Muted and unmuted critical icons should differ")
+	}
+}
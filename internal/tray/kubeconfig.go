@@ -0,0 +1,92 @@
+package tray
+
+import (
+	"context"
+	"log"
+
+	"github.com/mattlqx/k8s-tray/internal/kubernetes"
+)
+
+// watchKubeconfig reloads the context menu and client when kubeconfig files change
+func (m *Manager) watchKubeconfig(ctx context.Context) {
+	changes, err := kubernetes.WatchKubeconfig(ctx, m.config, kubernetes.DefaultKubeconfigDebounce)
+	if err != nil {
+		log.Printf("Failed to watch kubeconfig, context changes need a manual refresh: %v", err)
+		return
+	}
+
+	for change := range changes {
+		m.applyKubeconfigChange(ctx, change)
+	}
+}
+
+// applyKubeconfigChange rebuilds the context list and recreates clients whose
+// context was rewritten, following kubectl's current-context if configured
+func (m *Manager) applyKubeconfigChange(ctx context.Context, change kubernetes.KubeconfigChange) {
+	log.Printf("Kubeconfig changed: current-context %q, changed contexts %v", change.CurrentContext, change.ChangedContexts)
+
+	active, err := m.k8sClient.GetCurrentContext()
+	if err != nil {
+		log.Printf("Failed to get current context: %v", err)
+	}
+
+	switch {
+	case followCurrentContext(change, active, m.followsCurrentContext()):
+		log.Printf("Following current-context to %s", change.CurrentContext)
+		m.reloadClient(change.CurrentContext)
+	case contains(change.ChangedContexts, active):
+		log.Printf("Credentials of context %s changed, reconnecting", active)
+		m.reloadClient(active)
+	default:
+		// Monitored contexts get fresh clients if their definitions changed
+		if m.monitorsAffected(change.ChangedContexts) {
+			m.restartClusterMonitors()
+		}
+	}
+
+	m.refreshContextMenu(ctx)
+}
+
+// followsCurrentContext reports whether the active context tracks kubectl's current-context
+func (m *Manager) followsCurrentContext() bool {
+	return m.config.Context == "" && m.config.FollowCurrentContext
+}
+
+// followCurrentContext reports whether the change switched kubectl's
+// current-context away from the active context and the tray should follow it
+func followCurrentContext(change kubernetes.KubeconfigChange, active string, follow bool) bool {
+	return follow && change.CurrentContextChanged && change.CurrentContext != "" && change.CurrentContext != active
+}
+
+// reloadClient recreates the client for the named context from the kubeconfig
+func (m *Manager) reloadClient(contextName string) {
+	newClient, err := m.newSource(m.config, contextName)
+	if err != nil {
+		log.Printf("Failed to recreate client after kubeconfig change: %v", err)
+		return
+	}
+
+	m.switchMu.Lock()
+	defer m.switchMu.Unlock()
+	m.replaceClient(newClient)
+}
+
+// monitorsAffected reports whether any monitored context is among names
+func (m *Manager) monitorsAffected(names []string) bool {
+	for _, mon := range m.clusterMonitors {
+		if contains(names, mon.name) {
+			return true
+		}
+	}
+	return false
+}
+
+// contains reports whether s is in list
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,34 @@
+package tray
+
+import (
+	"testing"
+
+	"github.com/mattlqx/k8s-tray/internal/kubernetes"
+)
+
+func TestFollowCurrentContext(t *testing.T) {
+	switched := kubernetes.KubeconfigChange{CurrentContext: "prod", CurrentContextChanged: true}
+
+	tests := []struct {
+		name     string
+		change   kubernetes.KubeconfigChange
+		active   string
+		follow   bool
+		expected bool
+	}{
+		{"Follows switch", switched, "dev", true, true},
+		{"Following disabled", switched, "dev", false, false},
+		{"Already on new context", switched, "prod", true, false},
+		{"Current context unchanged", kubernetes.KubeconfigChange{CurrentContext: "prod"}, "dev", true, false},
+		{"Current context removed", kubernetes.KubeconfigChange{CurrentContextChanged: true}, "dev", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := followCurrentContext(tt.change, tt.active, tt.follow)
+			if result != tt.expected {
+				t.Errorf("Expected %t, got %t", tt.expected, result)
+			}
+		})
+	}
+}
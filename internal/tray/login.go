@@ -0,0 +1,110 @@
+package tray
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os/exec"
+	"runtime"
+	"time"
+
+	"fyne.io/systray"
+	"github.com/mattlqx/k8s-tray/pkg/models"
+)
+
+// loginTimeout bounds login commands, which may wait for a browser-based SSO flow
+const loginTimeout = 5 * time.Minute
+
+// updateLoginItem shows the Log In action while the current context needs a login
+func (m *Manager) updateLoginItem(state models.ConnectionState) {
+	if state != models.ConnectionLoginRequired {
+		m.loginItem.Hide()
+		return
+	}
+
+	contextName, err := m.k8sClient.GetCurrentContext()
+	if err != nil {
+		log.Printf("Failed to get current context: %v", err)
+		return
+	}
+
+	setLoginItem(m.loginItem, m.config.ContextSettings(contextName).LoginCommand, contextName)
+	m.loginItem.Show()
+}
+
+// setLoginItem titles a login item, disabling it when no login command is configured
+func setLoginItem(item *systray.MenuItem, command, contextName string) {
+	item.SetTitle(formatLoginTitle(command, contextName))
+	if command == "" {
+		item.Disable()
+	} else {
+		item.Enable()
+	}
+}
+
+// formatLoginTitle formats a login item, pointing at the setting to add when
+// the context has no login command
+func formatLoginTitle(command, contextName string) string {
+	if command == "" {
+		return fmt.Sprintf("🔑 Log In: set contexts.%s.login_command", contextName)
+	}
+	return fmt.Sprintf("🔑 Log In to %s", contextName)
+}
+
+// logIn runs the context's login command and reconnects once it succeeds
+func (m *Manager) logIn(ctx context.Context, contextName string, item *systray.MenuItem) {
+	command := m.config.ContextSettings(contextName).LoginCommand
+	if command == "" {
+		return
+	}
+
+	item.SetTitle(fmt.Sprintf("🔑 Logging in to %s...", contextName))
+	item.Disable()
+
+	if err := runLoginCommand(ctx, command); err != nil {
+		log.Printf("Login to context %s failed: %v", contextName, err)
+		setLoginItem(item, command, contextName)
+		item.SetTitle(fmt.Sprintf("🔑 Log In to %s (last attempt failed)", contextName))
+		return
+	}
+
+	log.Printf("Logged in to context %s, reconnecting", contextName)
+	setLoginItem(item, command, contextName)
+	item.Hide()
+	m.reconnect(contextName)
+}
+
+// reconnect recreates the clients of the named context so new credentials are picked up
+func (m *Manager) reconnect(contextName string) {
+	if active, err := m.k8sClient.GetCurrentContext(); err == nil && active == contextName {
+		// Replacing the client also restarts the monitored clusters
+		m.reloadClient(contextName)
+		return
+	}
+
+	if m.monitorsAffected([]string{contextName}) {
+		m.restartClusterMonitors()
+	}
+}
+
+// runLoginCommand runs a login command through the shell and logs its output
+func runLoginCommand(ctx context.Context, command string) error {
+	ctx, cancel := context.WithTimeout(ctx, loginTimeout)
+	defer cancel()
+
+	// #nosec G204 -- the command comes from the user's own configuration
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	if runtime.GOOS == osWindows {
+		// #nosec G204 -- the command comes from the user's own configuration
+		cmd = exec.CommandContext(ctx, "cmd", "/c", command)
+	}
+
+	output, err := cmd.CombinedOutput()
+	if len(output) > 0 {
+		log.Printf("Login command output:\n%s", output)
+	}
+	if err != nil {
+		return fmt.Errorf("login command %q failed: %w", command, err)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package tray
+
+import (
+	"context"
+	"runtime"
+	"testing"
+)
+
+func TestFormatLoginTitle(t *testing.T) {
+	tests := []struct {
+		name     string
+		command  string
+		expected string
+	}{
+		{name: "Configured", command: "aws sso login --profile prod", expected: "🔑 Log In to prod-eu"},
+		{name: "Not configured", command: "", expected: "🔑 Log In: set contexts.prod-eu.login_command"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := formatLoginTitle(tt.command, "prod-eu"); result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestRunLoginCommand(t *testing.T) {
+	if runtime.GOOS == osWindows {
+		t.Skip("Login commands are run through sh in this test")
+	}
+
+	if err := runLoginCommand(context.Background(), "echo logged in"); err != nil {
+		t.Errorf("Expected successful login, got %v", err)
+	}
+	if err := runLoginCommand(context.Background(), "exit 1"); err == nil {
+		t.Error("Expected an error when the login command fails")
+	}
+}
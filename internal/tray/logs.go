@@ -0,0 +1,181 @@
+package tray
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mattlqx/k8s-tray/pkg/models"
+)
+
+// maxLogFiles is how many log files are kept in the cache directory
+const maxLogFiles = 20
+
+// viewPodLogs fetches the pod's container logs, writes them to a file in the
+// cache directory and opens it with the configured viewer. The fetch is not
+// tied to the pod submenus, which are rebuilt while it runs; the client bounds
+// each container's log request with the request timeout.
+func (m *Manager) viewPodLogs(pod models.PodDetail) {
+	logs, err := m.k8sClient.GetPodLogs(m.mainCtx, pod.Namespace, pod.Name, m.config.LogTailLines)
+	if err != nil {
+		log.Printf("Failed to get logs for pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		return
+	}
+
+	dir, err := logCacheDir()
+	if err != nil {
+		log.Printf("Failed to create log cache directory: %v", err)
+		return
+	}
+
+	path, err := writePodLogs(dir, pod, logs, time.Now())
+	if err != nil {
+		log.Printf("Failed to write logs for pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		return
+	}
+	if err := pruneLogFiles(dir, maxLogFiles); err != nil {
+		log.Printf("Failed to remove old log files: %v", err)
+	}
+
+	if err := openFile(m.config.LogViewer, path); err != nil {
+		log.Printf("Failed to open log file %s: %v", path, err)
+		return
+	}
+
+	log.Printf("Opened logs for pod %s/%s: %s", pod.Namespace, pod.Name, path)
+}
+
+// pruneLogFiles removes all but the keep most recently written log files in dir
+func pruneLogFiles(dir string, keep int) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	type logFile struct {
+		path    string
+		modTime time.Time
+	}
+	var files []logFile
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".log" {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, logFile{path: filepath.Join(dir, entry.Name()), modTime: info.ModTime()})
+	}
+	if len(files) <= keep {
+		return nil
+	}
+
+	// Newest first
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime.After(files[j].modTime) })
+	for _, file := range files[keep:] {
+		if err := os.Remove(file.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// logCacheDir returns the directory log files are written to, creating it if needed
+func logCacheDir() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		cacheDir = os.TempDir()
+	}
+
+	dir := filepath.Join(cacheDir, "k8s-tray", "logs")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// writePodLogs writes the pod's logs to a timestamped file in dir and returns its path
+func writePodLogs(dir string, pod models.PodDetail, logs []models.ContainerLog, now time.Time) (string, error) {
+	name := fmt.Sprintf("%s_%s_%s.log", pod.Namespace, pod.Name, now.Format("20060102-150405"))
+	path := filepath.Join(dir, name)
+
+	if err := os.WriteFile(path, []byte(formatPodLogs(pod, logs, now)), 0600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// formatPodLogs renders the logs of all containers with a header per container
+func formatPodLogs(pod models.PodDetail, logs []models.ContainerLog, now time.Time) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Pod: %s\nNamespace: %s\nPhase: %s\n", pod.Name, pod.Namespace, pod.Phase)
+	if pod.Reason != "" {
+		fmt.Fprintf(&b, "Reason: %s\n", pod.Reason)
+	}
+	fmt.Fprintf(&b, "Fetched: %s\n", now.Format(time.RFC3339))
+
+	if len(logs) == 0 {
+		b.WriteString("\nNo containers found\n")
+	}
+
+	for _, entry := range logs {
+		header := entry.Container
+		if entry.Init {
+			header += " (init)"
+		}
+		if entry.Previous {
+			header += " (previous)"
+		}
+		fmt.Fprintf(&b, "\n===== %s =====\n", header)
+
+		switch {
+		case entry.Error != "":
+			fmt.Fprintf(&b, "Failed to get logs: %s\n", entry.Error)
+		case entry.Content == "":
+			b.WriteString("(no output)\n")
+		default:
+			b.WriteString(entry.Content)
+			if !strings.HasSuffix(entry.Content, "\n") {
+				b.WriteString("\n")
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// openFile opens path with the viewer command, or the platform's default
+// application when no viewer is configured
+func openFile(viewer, path string) error {
+	var cmd *exec.Cmd
+	if args := strings.Fields(viewer); len(args) > 0 {
+		cmd = exec.Command(args[0], append(args[1:], path)...)
+	} else {
+		switch runtime.GOOS {
+		case "darwin":
+			cmd = exec.Command("open", path)
+		case osWindows:
+			cmd = exec.Command("cmd", "/c", "start", "", path)
+		default:
+			cmd = exec.Command("xdg-open", path)
+		}
+	}
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	// Reap the viewer process without blocking the menu
+	go func() {
+		_ = cmd.Wait()
+	}()
+	return nil
+}
@@ -0,0 +1,96 @@
+package tray
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattlqx/k8s-tray/pkg/models"
+)
+
+func TestFormatPodLogs(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	pod := models.PodDetail{Name: "api-7f9", Namespace: "prod", Phase: "Running", Reason: "CrashLoopBackOff"}
+	logs := []models.ContainerLog{
+		{Container: "migrate", Init: true, Content: "migrated\n"},
+		{Container: "api", Previous: true, Content: "panic: boom"},
+		{Container: "api", Content: ""},
+		{Container: "sidecar", Error: "container not started"},
+	}
+
+	result := formatPodLogs(pod, logs, now)
+
+	expected := []string{
+		"Pod: api-7f9\nNamespace: prod\nPhase: Running\nReason: CrashLoopBackOff\nFetched: 2024-01-02T03:04:05Z\n",
+		"===== migrate (init) =====\nmigrated\n",
+		"===== api (previous) =====\npanic: boom\n",
+		"===== api =====\n(no output)\n",
+		"===== sidecar =====\nFailed to get logs: container not started\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, result)
+		}
+	}
+}
+
+func TestWritePodLogs(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	pod := models.PodDetail{Name: "api-7f9", Namespace: "prod"}
+
+	path, err := writePodLogs(dir, pod, []models.ContainerLog{{Container: "api", Content: "hello\n"}}, now)
+	if err != nil {
+		t.Fatalf("Failed to write logs: %v", err)
+	}
+
+	if expected := filepath.Join(dir, "prod_api-7f9_20240102-030405.log"); path != expected {
+		t.Errorf("Expected path '%s', got '%s'", expected, path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	if !strings.Contains(string(data), "hello\n") {
+		t.Errorf("Expected log file to contain container output, got:\n%s", data)
+	}
+}
+
+func TestPruneLogFiles(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	for i, name := range []string{"prod_api_1.log", "prod_api_2.log", "prod_web_1.log", "prod_web_2.log"} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("logs\n"), 0600); err != nil {
+			t.Fatalf("Failed to write log file: %v", err)
+		}
+		// Written a minute apart, oldest first
+		modTime := now.Add(time.Duration(i-4) * time.Minute)
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatalf("Failed to set modification time: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), nil, 0600); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	if err := pruneLogFiles(dir, 2); err != nil {
+		t.Fatalf("Failed to prune log files: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Failed to read directory: %v", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	// Only the newest log files are kept; other files are left alone
+	if expected := "notes.txt prod_web_1.log prod_web_2.log"; strings.Join(names, " ") != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, strings.Join(names, " "))
+	}
+}
@@ -1,20 +1,51 @@
 package tray
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"log"
+	"math"
+	"path/filepath"
 	"runtime"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"fyne.io/systray"
 	"github.com/mattlqx/k8s-tray/internal/config"
+	"github.com/mattlqx/k8s-tray/internal/hooks"
 	"github.com/mattlqx/k8s-tray/internal/kubernetes"
+	"github.com/mattlqx/k8s-tray/internal/notify"
 	"github.com/mattlqx/k8s-tray/pkg/models"
 )
 
 const osWindows = "windows"
 
+// removeMenuItems removes menu items for good; hidden items would pile up as
+// submenus are rebuilt. Keys are removed in reverse order, so that an item
+// keyed "parent/child" is removed before its parent.
+func removeMenuItems[K cmp.Ordered](items map[K]*systray.MenuItem) {
+	keys := make([]K, 0, len(items))
+	for key := range items {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	slices.Reverse(keys)
+
+	for _, key := range keys {
+		items[key].Remove()
+	}
+}
+
+// contextSwitchTimeout bounds the connection check before switching contexts
+const contextSwitchTimeout = 10 * time.Second
+
+// maxEventsDisplayed limits how many warning events are listed in the Recent Events submenu
+const maxEventsDisplayed = 15
+
 // Pod phase constants
 const (
 	podPhaseRunning   = "Running"
@@ -25,9 +56,12 @@ const (
 
 // Manager handles the system tray functionality
 type Manager struct {
-	k8sClient *kubernetes.Client
+	k8sClient ClusterSource
 	config    *config.Config
 
+	// Creates the source for a newly selected context
+	newSource SourceFactory
+
 	// Menu items
 	statusItem        *systray.MenuItem
 	clusterItem       *systray.MenuItem
@@ -40,20 +74,61 @@ type Manager struct {
 	podsPendingItem   *systray.MenuItem
 	podsCompletedItem *systray.MenuItem
 	podsFailedItem    *systray.MenuItem
+	podsCrashLoopItem *systray.MenuItem
+	podsImagePullItem *systray.MenuItem
+	podsConfigErrItem *systray.MenuItem
+	podsOOMKilledItem *systray.MenuItem
 	refreshItem       *systray.MenuItem
 	dataAgeItem       *systray.MenuItem
+	loginItem         *systray.MenuItem
 	helpItem          *systray.MenuItem
 	quitItem          *systray.MenuItem
 
+	// Node submenu items
+	nodesMenu *systray.MenuItem
+	nodeItems map[string]*systray.MenuItem
+
+	// Workload submenu items, grouped under one item per kind
+	workloadsMenu     *systray.MenuItem
+	workloadKindItems map[string]*systray.MenuItem
+	workloadItems     map[string]*systray.MenuItem
+
+	// Job submenu items
+	jobsMenu *systray.MenuItem
+	jobItems map[string]*systray.MenuItem
+
+	// Recent warning events submenu items
+	eventsMenu *systray.MenuItem
+	eventItems map[string]*systray.MenuItem
+
+	// Recent changes between refreshes, oldest first, guarded by statusMu
+	changesMenu   *systray.MenuItem
+	changeItems   map[string]*systray.MenuItem
+	recentChanges []models.Change
+
+	// Features disabled by RBAC
+	permissionsMenu *systray.MenuItem
+	permissionItems map[string]*systray.MenuItem
+
+	// Monitored cluster sections, one per context in MonitoredContexts
+	clustersLabel   *systray.MenuItem
+	clusterMonitors []*clusterMonitor
+	clustersMu      sync.Mutex
+	clustersCancel  context.CancelFunc // Guarded by clustersMu
+
 	// Namespace submenu items
 	namespaceMenu      *systray.MenuItem
 	namespaceItems     map[string]*systray.MenuItem
 	namespaceSeparator *systray.MenuItem
 
-	// Context submenu items
+	// Context submenu items, guarded by clustersMu
 	contextMenu  *systray.MenuItem
 	contextItems map[string]*systray.MenuItem
 
+	// Kubeconfig file each context was loaded from, guarded by clustersMu
+	contextSources      map[string]models.KubeContext
+	multipleKubeconfigs bool
+
 	// Settings submenu items
 	settingsMenu  *systray.MenuItem
 	intervalItems map[time.Duration]*systray.MenuItem
@@ -64,29 +139,80 @@ type Manager struct {
 	podsPendingSubmenu   map[string]*systray.MenuItem
 	podsCompletedSubmenu map[string]*systray.MenuItem
 	podsFailedSubmenu    map[string]*systray.MenuItem
+	podsCrashLoopSubmenu map[string]*systray.MenuItem
+	podsImagePullSubmenu map[string]*systray.MenuItem
+	podsConfigErrSubmenu map[string]*systray.MenuItem
+	podsOOMKilledSubmenu map[string]*systray.MenuItem
+
+	// Cancels click handlers of the current pod submenu actions
+	podActionsCancel context.CancelFunc
 
 	// Monitoring control
 	intervalChanged chan time.Duration
 
+	// Serializes applying refresh results, which overlap briefly while the
+	// monitoring loop restarts, and guards currentStatus, notified,
+	// recentChanges and hookConnection
+	statusMu sync.Mutex
+
 	// Current state
 	currentStatus   *models.ClusterStatus
-	currentHealth   models.HealthStatus
+	currentHealth   models.HealthStatus    // Guarded by clustersMu
+	connectionState models.ConnectionState // Guarded by clustersMu
 	lastRefreshTime time.Time
+	nextRetry       time.Time // Set while refreshes back off after failures
+
+	// Desktop and webhook notifications of health changes; nil when disabled
+	notifier *notify.Notifier
+	notified healthSnapshot
+
+	// Runs the configured hook commands; nil without hooks
+	hookRunner     *hooks.Runner
+	hookConnection models.ConnectionState // Connection state hooks were last told about, guarded by statusMu
+
+	// Snoozing and acknowledging alerts
+	alertsMenu        *systray.MenuItem
+	snoozeShortItem   *systray.MenuItem
+	snoozeLongItem    *systray.MenuItem
+	snoozeHealthyItem *systray.MenuItem
+	acknowledgeItem   *systray.MenuItem
+	resumeItem        *systray.MenuItem
+	muteMu            sync.Mutex
+	mute              muteState
+
+	// Serializes icon and Alerts menu updates, which click handlers and the
+	// monitoring loops make, and guards iconMuted
+	iconMu    sync.Mutex
+	iconMuted bool // Whether the icon shows the muted overlay
 
 	// Context cancellation for ongoing requests
 	monitoringCtx    context.Context
 	monitoringCancel context.CancelFunc
 	mainCtx          context.Context
 
+	// Serializes context switches, client reloads and monitoring restarts, and
+	// guards monitoringCtx, monitoringCancel and the pending context switch
+	switchMu     sync.Mutex
+	switchCancel context.CancelFunc // Cancels the connection check of the pending switch
+	switchSeq    uint64             // Incremented by every switch; only the latest is applied
+
 	// Windows-specific visibility helper
 	showVisibilityHint bool
 }
 
 // NewManager creates a new tray manager
-func NewManager(k8sClient *kubernetes.Client, cfg *config.Config) *Manager {
-	return &Manager{
+func NewManager(k8sClient ClusterSource, cfg *config.Config) *Manager {
+	m := &Manager{
 		k8sClient:            k8sClient,
 		config:               cfg,
+		newSource:            newKubeconfigSource,
+		nodeItems:            make(map[string]*systray.MenuItem),
+		workloadKindItems:    make(map[string]*systray.MenuItem),
+		workloadItems:        make(map[string]*systray.MenuItem),
+		jobItems:             make(map[string]*systray.MenuItem),
+		eventItems:           make(map[string]*systray.MenuItem),
+		changeItems:          make(map[string]*systray.MenuItem),
+		permissionItems:      make(map[string]*systray.MenuItem),
 		namespaceItems:       make(map[string]*systray.MenuItem),
 		contextItems:         make(map[string]*systray.MenuItem),
 		intervalItems:        make(map[time.Duration]*systray.MenuItem),
@@ -95,10 +221,38 @@ func NewManager(k8sClient *kubernetes.Client, cfg *config.Config) *Manager {
 		podsPendingSubmenu:   make(map[string]*systray.MenuItem),
 		podsCompletedSubmenu: make(map[string]*systray.MenuItem),
 		podsFailedSubmenu:    make(map[string]*systray.MenuItem),
+		podsCrashLoopSubmenu: make(map[string]*systray.MenuItem),
+		podsImagePullSubmenu: make(map[string]*systray.MenuItem),
+		podsConfigErrSubmenu: make(map[string]*systray.MenuItem),
+		podsOOMKilledSubmenu: make(map[string]*systray.MenuItem),
 		intervalChanged:      make(chan time.Duration, 1),
 		currentHealth:        models.HealthUnknown,
 		showVisibilityHint:   runtime.GOOS == osWindows, // Show hint only on Windows
 	}
+
+	var backends []notify.Backend
+	if cfg.ShowNotifications {
+		backends = append(backends, notify.NewDesktopBackend(notificationAppName))
+	}
+	for _, webhookCfg := range cfg.Webhooks {
+		webhook, err := notify.NewWebhook(webhookCfg)
+		if err != nil {
+			log.Printf("Warning: skipping webhook: %v", err)
+			continue
+		}
+		backends = append(backends, webhook)
+	}
+	if len(backends) > 0 {
+		m.notifier = notify.New(backends...)
+	}
+
+	if len(cfg.Hooks) > 0 {
+		m.hookRunner = hooks.New(cfg.Hooks, cfg.MaxConcurrentHooks)
+	}
+
+	validateMaintenanceWindows(cfg)
+
+	return m
 }
 
 // OnReady is called when the systray is ready
@@ -130,16 +284,28 @@ func (m *Manager) OnReady(ctx context.Context) {
 
 	log.Printf("Initialized context menu")
 
+	// Reload contexts and credentials when kubeconfig files change
+	go m.watchKubeconfig(m.mainCtx)
+
 	// Initialize settings menu
 	go m.refreshSettingsMenu(m.mainCtx)
 
 	log.Printf("Initialized settings menu")
 
+	// Deliver notifications in the background
+	if m.notifier != nil {
+		go m.notifier.Run(m.mainCtx)
+	}
+
 	// Start monitoring
 	go m.startMonitoring(m.monitoringCtx)
 
 	log.Printf("Started monitoring")
 
+	// Start monitoring the other monitored contexts
+	m.startClusterMonitors(m.mainCtx)
+	m.handleClusterActions(m.mainCtx)
+
 	// Handle menu actions
 	go m.handleMenuActions(m.mainCtx)
 
@@ -172,6 +338,8 @@ func (m *Manager) OnExit() {
 	log.Println("Tray exiting...")
 
 	// Cancel any ongoing monitoring operations
+	m.switchMu.Lock()
+	defer m.switchMu.Unlock()
 	if m.monitoringCancel != nil {
 		m.monitoringCancel()
 	}
@@ -183,6 +351,10 @@ func (m *Manager) buildMenu() {
 	m.statusItem = systray.AddMenuItem("Status: Connecting...", "Current cluster status")
 	m.statusItem.Disable()
 
+	// Shown when the context's exec plugin or OIDC credentials need a login
+	m.loginItem = systray.AddMenuItem("🔑 Log In", "Run the context's login command and reconnect")
+	m.loginItem.Hide()
+
 	m.clusterItem = systray.AddMenuItem("Cluster: Unknown", "Current cluster")
 	m.clusterItem.Disable()
 
@@ -204,6 +376,9 @@ func (m *Manager) buildMenu() {
 		m.memoryItem.Disable()
 	}
 
+	// Node summary; each node is listed in its submenu
+	m.nodesMenu = systray.AddMenuItem("Nodes: Loading...", "Node readiness, pressure conditions and kubelet versions")
+
 	m.podsItem = systray.AddMenuItem("Pods: Loading...", "Pod status summary")
 	m.podsItem.Disable()
 
@@ -223,6 +398,37 @@ func (m *Manager) buildMenu() {
 	m.podsFailedItem = systray.AddMenuItem("  ❌ Failed: 0", "Pods that have failed to start or run")
 	// Keep enabled to allow submenu access on macOS
 
+	// Container issue categories, hidden until a pod hits one
+	m.podsCrashLoopItem = systray.AddMenuItem("  🔁 CrashLoopBackOff: 0", "Pods with containers restarting in a crash loop")
+	m.podsImagePullItem = systray.AddMenuItem("  📦 Image Pull Error: 0", "Pods whose container images cannot be pulled")
+	m.podsConfigErrItem = systray.AddMenuItem("  ⚙️ Config Error: 0", "Pods whose containers cannot be created from their configuration")
+	m.podsOOMKilledItem = systray.AddMenuItem("  💥 OOMKilled: 0", "Pods with containers killed for exceeding their memory limit")
+	m.podsCrashLoopItem.Hide()
+	m.podsImagePullItem.Hide()
+	m.podsConfigErrItem.Hide()
+	m.podsOOMKilledItem.Hide()
+
+	// Workload rollout status, grouped by kind in the submenu
+	m.workloadsMenu = systray.AddMenuItem("Workloads: Loading...", "Deployments, StatefulSets and DaemonSets in the current namespace")
+
+	// CronJob outcomes and standalone Jobs
+	m.jobsMenu = systray.AddMenuItem("Jobs: Loading...", "CronJobs and Jobs in the current namespace")
+
+	// Recent warning events, hidden until there are any
+	m.eventsMenu = systray.AddMenuItem("Recent Events: 0 warnings", "Recent Warning events in the current namespace")
+	m.eventsMenu.Hide()
+
+	// Changes since earlier refreshes, hidden until there are any
+	m.changesMenu = systray.AddMenuItem("Recent Changes: 0", "What changed between recent refreshes")
+	m.changesMenu.Hide()
+
+	// Features the user's permissions do not allow, hidden unless there are any
+	m.permissionsMenu = systray.AddMenuItem("Permissions: Limited", "Features disabled by RBAC")
+	m.permissionsMenu.Hide()
+
+	// Other monitored clusters, each with its own health and pod counts
+	m.buildClusterMenu()
+
 	systray.AddSeparator()
 
 	// Namespace selection
@@ -234,6 +440,7 @@ func (m *Manager) buildMenu() {
 	systray.AddSeparator()
 
 	// Actions
+	m.buildAlertsMenu()
 	m.refreshItem = systray.AddMenuItem("Refresh", "Refresh cluster status")
 	m.dataAgeItem = systray.AddMenuItem("Data Age: Unknown", "Time since last successful refresh")
 	m.dataAgeItem.Disable()
@@ -257,12 +464,28 @@ func (m *Manager) handleMenuActions(ctx context.Context) {
 			return
 		case <-m.refreshItem.ClickedCh:
 			// Rebuild monitoring loop
-			m.monitoringCancel()
-			m.monitoringCtx, m.monitoringCancel = context.WithCancel(ctx)
-			go m.startMonitoring(m.monitoringCtx)
+			m.switchMu.Lock()
+			m.restartMonitoring()
+			m.switchMu.Unlock()
 		case <-m.quitItem.ClickedCh:
 			systray.Quit()
 			return
+		case <-m.snoozeShortItem.ClickedCh:
+			m.snooze(shortSnooze)
+		case <-m.snoozeLongItem.ClickedCh:
+			m.snooze(longSnooze)
+		case <-m.snoozeHealthyItem.ClickedCh:
+			m.snoozeUntilHealthy()
+		case <-m.acknowledgeItem.ClickedCh:
+			m.acknowledgeIssues()
+		case <-m.resumeItem.ClickedCh:
+			m.resumeAlerts()
+		case <-m.alertsMenu.ClickedCh:
+			// Snooze and acknowledge actions are in the submenu
+		case <-m.loginItem.ClickedCh:
+			if contextName, err := m.k8sClient.GetCurrentContext(); err == nil {
+				go m.logIn(ctx, contextName, m.loginItem)
+			}
 		case <-m.namespaceMenu.ClickedCh:
 			go m.refreshNamespaceMenu(ctx)
 		case <-m.contextMenu.ClickedCh:
@@ -280,6 +503,22 @@ func (m *Manager) handleMenuActions(ctx context.Context) {
 			// Pod status items are now clickable but we don't need to do anything
 		case <-m.podsFailedItem.ClickedCh:
 			// Pod status items are now clickable but we don't need to do anything
+		case <-m.nodesMenu.ClickedCh:
+			// Node details are shown in the submenu
+		case <-m.workloadsMenu.ClickedCh:
+			// Workload details are shown in the submenu
+		case <-m.jobsMenu.ClickedCh:
+			// Job details are shown in the submenu
+		case <-m.eventsMenu.ClickedCh:
+			// Event details are shown in the submenu
+		case <-m.changesMenu.ClickedCh:
+			// Changes are shown in the submenu
+		case <-m.permissionsMenu.ClickedCh:
+			// Disabled features are shown in the submenu
+		case <-m.podsCrashLoopItem.ClickedCh:
+		case <-m.podsImagePullItem.ClickedCh:
+		case <-m.podsConfigErrItem.ClickedCh:
+		case <-m.podsOOMKilledItem.ClickedCh:
 		}
 
 		// Handle Windows help menu if it exists
@@ -293,59 +532,164 @@ func (m *Manager) handleMenuActions(ctx context.Context) {
 	}
 }
 
-// startMonitoring starts the periodic monitoring of cluster status
+// startMonitoring starts watch-driven monitoring of cluster status, with the
+// poll interval acting as a periodic resync and fallback. Refreshes back off
+// exponentially while the cluster keeps failing.
 func (m *Manager) startMonitoring(ctx context.Context) {
+	retry := newBackoff()
+	interval := m.config.PollInterval
+
 	// Initial refresh
-	m.refreshStatus(ctx)
+	err := m.refreshStatus(ctx)
+	if ctx.Err() != nil {
+		return
+	}
+	timer := time.NewTimer(m.refreshDelay(err, retry, interval))
+	defer timer.Stop()
 
-	// Set up periodic refresh with dynamic interval changes
-	ticker := time.NewTicker(m.config.PollInterval)
-	defer ticker.Stop()
+	// Subscribe to watch-driven updates; if this fails we keep polling
+	updates, err := m.k8sClient.Watch(ctx, kubernetes.DefaultWatchDebounce)
+	if err != nil {
+		log.Printf("Failed to start watching cluster, falling back to polling: %v", err)
+	}
 
-	// Set up a separate ticker for updating data age more frequently (every 10 seconds)
-	dataAgeTicker := time.NewTicker(10 * time.Second)
+	// Set up a separate ticker for updating data age more frequently than full refresh
+	dataAgeTicker := time.NewTicker(dataAgeInterval(retry.retrying()))
 	defer dataAgeTicker.Stop()
 
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case <-ticker.C:
-			m.refreshStatus(ctx)
+		case <-timer.C:
+			err := m.refreshStatus(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+			timer.Reset(m.refreshDelay(err, retry, interval))
+			dataAgeTicker.Reset(dataAgeInterval(retry.retrying()))
+		case status, ok := <-updates:
+			if !ok {
+				// Watch stopped; a nil channel blocks forever so only polling remains
+				updates = nil
+				continue
+			}
+			if !m.applyUpdate(ctx, status) {
+				return
+			}
+			if retry.retrying() {
+				// The cluster is reachable again, return to the poll interval
+				timer.Reset(m.refreshDelay(nil, retry, interval))
+				dataAgeTicker.Reset(dataAgeInterval(false))
+			}
 		case <-dataAgeTicker.C:
 			// Update data age display more frequently than full refresh
 			m.updateDataAge()
-		case newInterval := <-m.intervalChanged:
-			// Reset ticker with new interval
-			ticker.Stop()
-			ticker = time.NewTicker(newInterval)
-			log.Printf("Updated monitoring interval to: %s", newInterval)
+		case interval = <-m.intervalChanged:
+			// Reset timer with new interval unless backing off
+			if !retry.retrying() {
+				timer.Reset(interval)
+			}
+			log.Printf("Updated monitoring interval to: %s", interval)
 		}
 	}
 }
 
-// refreshStatus refreshes the cluster status
-func (m *Manager) refreshStatus(ctx context.Context) {
+// refreshDelay returns the delay before the next refresh after one completed
+// with err, backing off while the cluster keeps failing
+func (m *Manager) refreshDelay(err error, retry *backoff, interval time.Duration) time.Duration {
+	if err == nil {
+		retry.reset()
+		m.nextRetry = time.Time{}
+		m.updateDataAge()
+		return interval
+	}
+
+	delay := retry.next(interval)
+	m.nextRetry = time.Now().Add(delay)
+	log.Printf("Retrying in %s after %d consecutive failures", delay.Round(time.Second), retry.failures)
+	m.updateDataAge()
+	return delay
+}
+
+// dataAgeInterval returns how often the data age item is updated; every second
+// while counting down to a retry
+func dataAgeInterval(retrying bool) time.Duration {
+	if retrying {
+		return time.Second
+	}
+	return 10 * time.Second
+}
+
+// restartMonitoring cancels the monitoring loop and starts a new one, so that
+// no refresh started before is applied afterwards; m.switchMu must be held
+func (m *Manager) restartMonitoring() {
+	m.monitoringCancel()
+	m.monitoringCtx, m.monitoringCancel = context.WithCancel(m.mainCtx)
+	go m.startMonitoring(m.monitoringCtx)
+}
+
+// refreshStatus refreshes the cluster status. Refreshes cancelled because
+// monitoring restarted or stopped are not applied.
+func (m *Manager) refreshStatus(ctx context.Context) error {
 	status, err := m.k8sClient.GetClusterStatus(ctx)
+
+	m.statusMu.Lock()
+	defer m.statusMu.Unlock()
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
 	if err != nil {
 		log.Printf("Failed to get cluster status: %v", err)
 		m.updateError(err)
-		return
+		m.notifyHealthChange(nil, nil, err)
+		m.runConnectionHooks(err)
+		return err
 	}
 
 	log.Printf("Refreshed cluster status... %+v", status.PodStatus)
 
+	m.applyStatus(status)
+	return nil
+}
+
+// applyUpdate applies a status delivered by the watch unless monitoring was
+// cancelled, reporting whether it was applied
+func (m *Manager) applyUpdate(ctx context.Context, status *models.ClusterStatus) bool {
+	m.statusMu.Lock()
+	defer m.statusMu.Unlock()
+	if ctx.Err() != nil {
+		return false
+	}
+	m.applyStatus(status)
+	return true
+}
+
+// applyStatus records a freshly computed status, from a refresh or the
+// watch, updates the display and notifies about health changes; m.statusMu
+// must be held
+func (m *Manager) applyStatus(status *models.ClusterStatus) {
 	// Record the time of successful refresh
 	m.lastRefreshTime = time.Now()
 
+	// Record what changed since the previous refresh
+	changes := models.Diff(m.currentStatus, status)
+	m.recordChanges(changes)
+	m.notifyHealthChange(status, changes, nil)
+	m.runHooks(m.currentStatus, status, changes)
+
 	m.currentStatus = status
 	m.updateDisplay(status)
 }
 
 // updateDisplay updates the tray display with current status
 func (m *Manager) updateDisplay(status *models.ClusterStatus) {
-	m.currentHealth = status.HealthStatus
-	m.updateIcon(status.HealthStatus)
+	m.setPrimaryState(status.HealthStatus, models.ConnectionOK)
+	m.refreshIcon()
+	m.loginItem.Hide()
+
+	// Update this context's monitored cluster section, if it has one
+	m.updatePrimaryCluster(status, nil)
 
 	// Get display name for namespace
 	namespaceDisplay := m.config.Namespace
@@ -364,19 +708,21 @@ func (m *Manager) updateDisplay(status *models.ClusterStatus) {
 	// Add resource stats to tooltip if available
 	if m.config.ShowMetrics && status.Resources != nil {
 		if status.Resources.CPU != nil {
-			tooltip += fmt.Sprintf("\nCPU: %.1f/%.1f cores (%.1f%%)",
-				status.Resources.CPU.Used,
-				status.Resources.CPU.Available,
-				status.Resources.CPU.Percentage)
+			tooltip += "\n" + formatResourceStat("CPU", "cores", status.Resources.CPU, status.Resources.MetricsAvailable)
 		}
 		if status.Resources.Memory != nil {
-			tooltip += fmt.Sprintf("\nMemory: %.1f/%.1f GB (%.1f%%)",
-				status.Resources.Memory.Used,
-				status.Resources.Memory.Available,
-				status.Resources.Memory.Percentage)
+			tooltip += "\n" + formatResourceStat("Memory", "GB", status.Resources.Memory, status.Resources.MetricsAvailable)
 		}
 	}
 
+	// Add node summary to tooltip if available
+	if status.NodeStatus != nil {
+		tooltip += "\n" + formatNodeSummary(status.NodeStatus)
+	}
+
+	// Add the health of other monitored clusters
+	tooltip += m.clusterTooltip()
+
 	// Add Windows-specific visibility hint if needed
 	if runtime.GOOS == osWindows && m.showVisibilityHint {
 		tooltip += "\n\n💡 Tip: Pin this icon to the visible tray area for easier access"
@@ -392,25 +738,41 @@ func (m *Manager) updateDisplay(status *models.ClusterStatus) {
 	m.namespaceItem.SetTitle(fmt.Sprintf("Namespace: %s", namespaceDisplay))
 
 	// Update resource stats if enabled and available
-	if m.config.ShowMetrics && status.Resources != nil {
+	if m.config.ShowMetrics && !status.Permissions.Allowed(models.FeatureResources) {
+		m.cpuItem.SetTitle("CPU: No permission")
+		m.memoryItem.SetTitle("Memory: No permission")
+	} else if m.config.ShowMetrics && status.Resources != nil {
 		if status.Resources.CPU != nil {
-			m.cpuItem.SetTitle(fmt.Sprintf("CPU: %.1f/%.1f cores (%.1f%%)",
-				status.Resources.CPU.Used,
-				status.Resources.CPU.Available,
-				status.Resources.CPU.Percentage))
+			m.cpuItem.SetTitle(formatResourceStat("CPU", "cores", status.Resources.CPU, status.Resources.MetricsAvailable))
 		}
 		if status.Resources.Memory != nil {
-			m.memoryItem.SetTitle(fmt.Sprintf("Memory: %.1f/%.1f GB (%.1f%%)",
-				status.Resources.Memory.Used,
-				status.Resources.Memory.Available,
-				status.Resources.Memory.Percentage))
+			m.memoryItem.SetTitle(formatResourceStat("Memory", "GB", status.Resources.Memory, status.Resources.MetricsAvailable))
 		}
 	}
 
 	m.podsItem.SetTitle(fmt.Sprintf("Pods: %d total", status.PodStatus.Total))
 
-	// Update data age
+	// Update node summary and submenu
+	m.updateNodeSubmenu(status.NodeStatus)
+
+	// Update workload summary and submenu
+	m.updateWorkloadSubmenu(status.Workloads)
+
+	// Update job summary and submenu
+	m.updateJobSubmenu(status.Jobs)
+
+	// Update recent events submenu
+	m.updateEventSubmenu(status.Events)
+
+	// Update recent changes submenu
+	m.updateChangesSubmenu()
+
+	// Update disabled features submenu
+	m.updatePermissionsSubmenu(status.Permissions)
+
+	// Update data age, with the duration of each refresh phase in its tooltip
 	m.updateDataAge()
+	m.dataAgeItem.SetTooltip(formatTimings(status.Timings))
 
 	// Update individual pod status items with visual indicators
 	m.podsReadyItem.SetTitle(fmt.Sprintf("  🟢 Ready: %d", status.PodStatus.RunningReady))
@@ -418,6 +780,10 @@ func (m *Manager) updateDisplay(status *models.ClusterStatus) {
 	m.podsPendingItem.SetTitle(fmt.Sprintf("  ⏳ Pending: %d", status.PodStatus.Pending))
 	m.podsCompletedItem.SetTitle(fmt.Sprintf("  ✅ Completed: %d", status.PodStatus.Completed))
 	m.podsFailedItem.SetTitle(fmt.Sprintf("  ❌ Failed: %d", status.PodStatus.Failed))
+	m.podsCrashLoopItem.SetTitle(fmt.Sprintf("  🔁 CrashLoopBackOff: %d", status.PodStatus.CrashLoop))
+	m.podsImagePullItem.SetTitle(fmt.Sprintf("  📦 Image Pull Error: %d", status.PodStatus.ImagePullError))
+	m.podsConfigErrItem.SetTitle(fmt.Sprintf("  ⚙️ Config Error: %d", status.PodStatus.ConfigError))
+	m.podsOOMKilledItem.SetTitle(fmt.Sprintf("  💥 OOMKilled: %d", status.PodStatus.OOMKilled))
 
 	// Update pod submenus with individual pod names
 	m.updatePodSubmenus(status.PodStatus)
@@ -448,13 +814,50 @@ func (m *Manager) updateDisplay(status *models.ClusterStatus) {
 	} else {
 		m.podsFailedItem.Show()
 	}
+	if status.PodStatus.CrashLoop == 0 {
+		m.podsCrashLoopItem.Hide()
+	} else {
+		m.podsCrashLoopItem.Show()
+	}
+	if status.PodStatus.ImagePullError == 0 {
+		m.podsImagePullItem.Hide()
+	} else {
+		m.podsImagePullItem.Show()
+	}
+	if status.PodStatus.ConfigError == 0 {
+		m.podsConfigErrItem.Hide()
+	} else {
+		m.podsConfigErrItem.Show()
+	}
+	if status.PodStatus.OOMKilled == 0 {
+		m.podsOOMKilledItem.Hide()
+	} else {
+		m.podsOOMKilledItem.Show()
+	}
 }
 
-// updateError updates the display when an error occurs
+// formatResourceStat formats a resource row showing actual usage (when
+// metrics-server is available) alongside requested resources
+func formatResourceStat(label, unit string, stat *models.ResourceStat, metricsAvailable bool) string {
+	if !metricsAvailable {
+		return fmt.Sprintf("%s: %.1f/%.1f %s requested (%.1f%%)",
+			label, stat.Requested, stat.Available, unit, stat.RequestedPercentage)
+	}
+
+	return fmt.Sprintf("%s: %.1f/%.1f %s (%.1f%% used, %.1f%% requested)",
+		label, stat.Used, stat.Available, unit, stat.Percentage, stat.RequestedPercentage)
+}
+
+// updateError updates the display when an error occurs. Connection problems
+// are shown separately from cluster health so they do not look like failing pods.
 func (m *Manager) updateError(err error) {
-	m.updateIcon(models.HealthCritical)
-	systray.SetTooltip(fmt.Sprintf("K8s Tray - Error: %v", err))
-	m.statusItem.SetTitle(fmt.Sprintf("Status: Error - %v", err))
+	state := kubernetes.ClassifyError(err)
+	m.setPrimaryState(models.HealthUnknown, state)
+	m.refreshIcon()
+	m.updatePrimaryCluster(nil, err)
+	systray.SetTooltip(fmt.Sprintf("K8s Tray - %s\n%v\n%s%s", state, err, state.Hint(), m.clusterTooltip()))
+	m.statusItem.SetTitle(formatConnectionStatus(state))
+	m.updateLoginItem(state)
 
 	// Update data age even when there's an error
 	m.updateDataAge()
@@ -462,30 +865,90 @@ func (m *Manager) updateError(err error) {
 
 // updateDataAge updates the data age menu item
 func (m *Manager) updateDataAge() {
-	if m.lastRefreshTime.IsZero() {
-		m.dataAgeItem.SetTitle("Data Age: Unknown")
-		return
+	m.dataAgeItem.SetTitle(formatDataAge(m.lastRefreshTime, m.nextRetry, time.Now()))
+
+	// Snoozes expire and maintenance windows start and end between refreshes
+	m.updateAlertsMenu()
+
+	// Keep the monitored clusters' ages current too
+	m.updateClusterAges()
+}
+
+// formatDataAge formats the data age item, e.g. "Data Age: 2m ago · Retrying in 30s"
+func formatDataAge(lastRefresh, nextRetry, now time.Time) string {
+	title := "Data Age: Unknown"
+	if !lastRefresh.IsZero() {
+		title = fmt.Sprintf("Data Age: %s", formatAge(now.Sub(lastRefresh)))
 	}
 
-	age := time.Since(m.lastRefreshTime)
-	var ageStr string
+	if !nextRetry.IsZero() {
+		wait := nextRetry.Sub(now)
+		if wait < 0 {
+			wait = 0
+		}
+		title += fmt.Sprintf(" · Retrying in %.0fs", math.Ceil(wait.Seconds()))
+	}
+	return title
+}
 
+// formatAge formats a duration as a relative age, e.g. "5m ago"
+func formatAge(age time.Duration) string {
 	switch {
 	case age < time.Minute:
-		ageStr = fmt.Sprintf("%.0fs ago", age.Seconds())
+		return fmt.Sprintf("%.0fs ago", age.Seconds())
 	case age < time.Hour:
-		ageStr = fmt.Sprintf("%.0fm ago", age.Minutes())
+		return fmt.Sprintf("%.0fm ago", age.Minutes())
 	case age < 24*time.Hour:
-		ageStr = fmt.Sprintf("%.1fh ago", age.Hours())
+		return fmt.Sprintf("%.1fh ago", age.Hours())
 	default:
-		ageStr = fmt.Sprintf("%.1fd ago", age.Hours()/24)
+		return fmt.Sprintf("%.1fd ago", age.Hours()/24)
 	}
+}
+
+// formatConnectionStatus formats the status item for a connection problem,
+// e.g. "Status: Unreachable - Check your network or VPN connection"
+func formatConnectionStatus(state models.ConnectionState) string {
+	return fmt.Sprintf("Status: %s - %s", state, state.Hint())
+}
+
+// setPrimaryState records the health of the current context and whether it
+// could be reached
+func (m *Manager) setPrimaryState(health models.HealthStatus, state models.ConnectionState) {
+	m.clustersMu.Lock()
+	defer m.clustersMu.Unlock()
+	m.currentHealth = health
+	m.connectionState = state
+}
+
+// refreshIcon shows the aggregated health, or the connection problem icon when
+// a cluster cannot be reached and no other cluster is critical
+func (m *Manager) refreshIcon() {
+	m.iconMu.Lock()
+	defer m.iconMu.Unlock()
+	m.drawIcon()
+}
 
-	m.dataAgeItem.SetTitle(fmt.Sprintf("Data Age: %s", ageStr))
+// drawIcon draws the icon refreshIcon describes; m.iconMu must be held
+func (m *Manager) drawIcon() {
+	health := m.aggregateHealth()
+	if state := m.connectionProblem(); state != models.ConnectionOK && health != models.HealthCritical {
+		m.iconMuted = m.mutedIcon(time.Now())
+		log.Printf("Setting tray icon for connection state: %s (muted: %v)", state, m.iconMuted)
+		systray.SetIcon(getConnectionIcon(state, m.iconMuted))
+		return
+	}
+	m.drawHealthIcon(health)
 }
 
 // updateIcon updates the tray icon based on health status
 func (m *Manager) updateIcon(health models.HealthStatus) {
+	m.iconMu.Lock()
+	defer m.iconMu.Unlock()
+	m.drawHealthIcon(health)
+}
+
+// drawHealthIcon draws the icon for a health status; m.iconMu must be held
+func (m *Manager) drawHealthIcon(health models.HealthStatus) {
 	var iconData []byte
 
 	switch health {
@@ -499,7 +962,13 @@ func (m *Manager) updateIcon(health models.HealthStatus) {
 		iconData = getGrayIcon()
 	}
 
-	log.Printf("Setting tray icon for health status: %s", health)
+	// Alerts muted by a snooze, acknowledgement or maintenance window
+	m.iconMuted = m.mutedIcon(time.Now())
+	if m.iconMuted {
+		iconData = getMutedIcon(health)
+	}
+
+	log.Printf("Setting tray icon for health status: %s (muted: %v)", health, m.iconMuted)
 	systray.SetIcon(iconData)
 }
 
@@ -512,14 +981,12 @@ func (m *Manager) refreshNamespaceMenu(ctx context.Context) {
 	}
 
 	// Clear existing items
-	for _, item := range m.namespaceItems {
-		item.Hide()
-	}
+	removeMenuItems(m.namespaceItems)
 	m.namespaceItems = make(map[string]*systray.MenuItem)
 
-	// Hide existing separator if it exists
+	// Remove existing separator if it exists
 	if m.namespaceSeparator != nil {
-		m.namespaceSeparator.Hide()
+		m.namespaceSeparator.Remove()
 	}
 
 	// Add "All Namespaces" option first
@@ -573,16 +1040,26 @@ func (m *Manager) refreshNamespaceMenu(ctx context.Context) {
 
 // refreshContextMenu refreshes the context submenu
 func (m *Manager) refreshContextMenu(ctx context.Context) {
-	contexts, err := m.k8sClient.GetAllContexts()
+	contexts, err := m.k8sClient.GetContextSources()
 	if err != nil {
 		log.Printf("Failed to get contexts: %v", err)
 		return
 	}
 
-	// Clear existing items
-	for _, item := range m.contextItems {
-		item.Hide()
+	// Record where each context comes from for the item titles
+	sources := make(map[string]models.KubeContext, len(contexts))
+	files := make(map[string]bool)
+	for _, kubeContext := range contexts {
+		sources[kubeContext.Name] = kubeContext
+		files[kubeContext.Source] = true
 	}
+	m.clustersMu.Lock()
+	defer m.clustersMu.Unlock()
+	m.contextSources = sources
+	m.multipleKubeconfigs = len(files) > 1
+
+	// Clear existing items
+	removeMenuItems(m.contextItems)
 	m.contextItems = make(map[string]*systray.MenuItem)
 
 	// Get current context
@@ -593,8 +1070,9 @@ func (m *Manager) refreshContextMenu(ctx context.Context) {
 	}
 
 	// Add context items
-	for _, contextName := range contexts {
-		item := m.contextMenu.AddSubMenuItem(contextName, fmt.Sprintf("Switch to context %s", contextName))
+	for _, kubeContext := range contexts {
+		contextName := kubeContext.Name
+		item := m.contextMenu.AddSubMenuItem(m.contextTitle(contextName), formatContextTooltip(kubeContext))
 		m.contextItems[contextName] = item
 
 		// Mark current selection
@@ -616,12 +1094,33 @@ func (m *Manager) refreshContextMenu(ctx context.Context) {
 	}
 }
 
+// formatContextTitle formats a context submenu entry, e.g. "prod (eks.yaml) ⚠️".
+// The file name is only shown when contexts come from more than one file; the
+// warning marks a name that is also defined, and ignored, in a later file.
+func formatContextTitle(name string, source models.KubeContext, showFile bool) string {
+	title := name
+	if showFile && source.Source != "" {
+		title += fmt.Sprintf(" (%s)", filepath.Base(source.Source))
+	}
+	if len(source.Shadowed) > 0 {
+		title += " ⚠️"
+	}
+	return title
+}
+
+// formatContextTooltip describes where a context is defined
+func formatContextTooltip(kubeContext models.KubeContext) string {
+	tooltip := fmt.Sprintf("Switch to context %s\nFrom: %s", kubeContext.Name, kubeContext.Source)
+	if len(kubeContext.Shadowed) > 0 {
+		tooltip += fmt.Sprintf("\nDuplicate name also defined in %s (ignored)", strings.Join(kubeContext.Shadowed, ", "))
+	}
+	return tooltip
+}
+
 // refreshSettingsMenu refreshes the settings submenu
 func (m *Manager) refreshSettingsMenu(ctx context.Context) {
 	// Clear existing items
-	for _, item := range m.intervalItems {
-		item.Hide()
-	}
+	removeMenuItems(m.intervalItems)
 	m.intervalItems = make(map[time.Duration]*systray.MenuItem)
 
 	// Define available refresh intervals
@@ -638,8 +1137,10 @@ func (m *Manager) refreshSettingsMenu(ctx context.Context) {
 		{5 * time.Minute, "5 minutes"},
 	}
 
-	// Add refresh interval section
-	m.settingsMenu.AddSubMenuItem("Refresh Interval:", "Current refresh interval setting").Disable()
+	// Add refresh interval section, keyed 0 so it is removed with the intervals
+	header := m.settingsMenu.AddSubMenuItem("Refresh Interval:", "Current refresh interval setting")
+	header.Disable()
+	m.intervalItems[0] = header
 
 	// Add interval items
 	for _, interval := range intervals {
@@ -715,19 +1216,54 @@ func (m *Manager) switchNamespace(namespace string) {
 		log.Printf("Failed to save config: %v", err)
 	}
 
+	// Restart monitoring, which watches the new namespace; refreshes of the
+	// old one still in flight are then discarded
+	m.switchMu.Lock()
+	defer m.switchMu.Unlock()
+	m.statusMu.Lock()
+	defer m.statusMu.Unlock()
+	m.restartMonitoring()
+
 	// Clear pod submenus to avoid showing stale pod data from the old namespace
 	m.clearPodSubmenus()
 
-	// Refresh status
-	m.refreshStatus(m.monitoringCtx)
+	// Pods and health of the new namespace are not changes to notify about
+	// or to list, but earlier changes are kept
+	m.notified = healthSnapshot{}
+	m.currentStatus = nil
+	m.hookConnection = models.ConnectionOK
 
 	log.Printf("Switched to namespace: %s", namespace)
 }
 
-// switchContext switches to a different context
+// switchContext switches to a different context. The new context is only
+// committed to the config, checkmarks and monitoring once it is reachable;
+// otherwise the previous context stays active and the error is shown. When
+// switches overlap, only the latest one is applied.
 func (m *Manager) switchContext(contextName string) {
+	ctx, seq := m.beginSwitch()
+	m.statusItem.SetTitle(fmt.Sprintf("Status: Connecting to %s...", contextName))
+
+	newClient, err := m.connectContext(ctx, contextName)
+
+	m.switchMu.Lock()
+	defer m.switchMu.Unlock()
+	if seq != m.switchSeq {
+		log.Printf("Switch to context %s was superseded by a later switch", contextName)
+		return
+	}
+	m.switchCancel()
+	m.switchCancel = nil
+
+	if err != nil {
+		log.Printf("Failed to switch to context %s, keeping current context: %v", contextName, err)
+		m.statusItem.SetTitle(fmt.Sprintf("Status: Cannot switch to %s - %s", contextName, kubernetes.ClassifyError(err)))
+		return
+	}
+
 	// Uncheck previous selection
 	currentContext, _ := m.k8sClient.GetCurrentContext()
+	m.clustersMu.Lock()
 	if m.config.Context == "" {
 		// If no context is set in config, use the current context from kubeconfig
 		if prevItem, exists := m.contextItems[currentContext]; exists {
@@ -746,25 +1282,63 @@ func (m *Manager) switchContext(contextName string) {
 	if newItem, exists := m.contextItems[contextName]; exists {
 		newItem.Check()
 	}
+	m.clustersMu.Unlock()
 
 	// Save configuration
 	if err := m.config.Save(); err != nil {
 		log.Printf("Failed to save config: %v", err)
 	}
 
-	// Need to recreate the Kubernetes client with the new context
-	newClient, err := kubernetes.NewClient(m.config)
+	m.replaceClient(newClient)
+
+	log.Printf("Switched to context: %s", contextName)
+}
+
+// beginSwitch cancels the connection check of a pending context switch and
+// returns the context and sequence number of a new switch
+func (m *Manager) beginSwitch() (context.Context, uint64) {
+	m.switchMu.Lock()
+	defer m.switchMu.Unlock()
+
+	if m.switchCancel != nil {
+		m.switchCancel()
+	}
+	ctx, cancel := context.WithCancel(m.mainCtx)
+	m.switchCancel = cancel
+	m.switchSeq++
+	return ctx, m.switchSeq
+}
+
+// connectContext creates a client for the context and checks that the cluster
+// answers within contextSwitchTimeout
+func (m *Manager) connectContext(ctx context.Context, contextName string) (ClusterSource, error) {
+	newClient, err := m.newSource(m.config, contextName)
 	if err != nil {
-		log.Printf("Failed to create new client with context %s: %v", contextName, err)
-		return
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, contextSwitchTimeout)
+	defer cancel()
+
+	if err := newClient.TestConnection(ctx); err != nil {
+		return nil, err
 	}
 
+	return newClient, nil
+}
+
+// replaceClient swaps in a new cluster source and restarts all monitoring with
+// it; m.switchMu must be held
+func (m *Manager) replaceClient(newClient ClusterSource) {
 	// Update the client
 	m.k8sClient = newClient
 
+	// Stop monitoring the other contexts; the new primary may be one of them
+	m.stopClusterMonitors()
+
 	// Cancel any existing monitoring operations to prevent stale data updates
 	if m.monitoringCancel != nil {
-		log.Printf("Cancelling existing monitoring operations for context switch")
+		log.Printf("Cancelling existing monitoring operations for client change")
 		m.monitoringCancel()
 	}
 
@@ -776,11 +1350,10 @@ func (m *Manager) switchContext(contextName string) {
 
 	// Restart monitoring with the new context
 	go m.startMonitoring(m.monitoringCtx)
+	m.startClusterMonitors(m.mainCtx)
 
 	// Refresh namespace menu since we switched clusters
 	go m.refreshNamespaceMenu(m.mainCtx)
-
-	log.Printf("Switched to context: %s", contextName)
 }
 
 // resetMenuState resets all menu items to their initial/loading state
@@ -798,6 +1371,33 @@ func (m *Manager) resetMenuState() {
 		m.memoryItem.SetTitle("Memory: Loading...")
 	}
 
+	// Reset node items
+	m.nodesMenu.SetTitle("Nodes: Loading...")
+	m.clearNodeSubmenu()
+
+	// Reset workload items
+	m.workloadsMenu.SetTitle("Workloads: Loading...")
+	m.clearWorkloadSubmenu()
+
+	// Reset job items
+	m.jobsMenu.SetTitle("Jobs: Loading...")
+	m.clearJobSubmenu()
+
+	// Reset event items
+	m.eventsMenu.Hide()
+	m.clearEventSubmenu()
+
+	// Reset recent changes; they belong to the old context
+	m.statusMu.Lock()
+	m.recentChanges = nil
+	m.changesMenu.Hide()
+	m.clearChangesSubmenu()
+	m.statusMu.Unlock()
+
+	// Reset permission items
+	m.permissionsMenu.Hide()
+	m.clearPermissionsSubmenu()
+
 	// Reset pod status items
 	m.podsItem.SetTitle("Pods: Loading...")
 	m.podsReadyItem.SetTitle("  🟢 Ready: 0")
@@ -805,6 +1405,10 @@ func (m *Manager) resetMenuState() {
 	m.podsPendingItem.SetTitle("  ⏳ Pending: 0")
 	m.podsCompletedItem.SetTitle("  ✅ Completed: 0")
 	m.podsFailedItem.SetTitle("  ❌ Failed: 0")
+	m.podsCrashLoopItem.SetTitle("  🔁 CrashLoopBackOff: 0")
+	m.podsImagePullItem.SetTitle("  📦 Image Pull Error: 0")
+	m.podsConfigErrItem.SetTitle("  ⚙️ Config Error: 0")
+	m.podsOOMKilledItem.SetTitle("  💥 OOMKilled: 0")
 
 	// Hide all pod status items initially
 	m.podsReadyItem.Hide()
@@ -812,6 +1416,10 @@ func (m *Manager) resetMenuState() {
 	m.podsPendingItem.Hide()
 	m.podsCompletedItem.Hide()
 	m.podsFailedItem.Hide()
+	m.podsCrashLoopItem.Hide()
+	m.podsImagePullItem.Hide()
+	m.podsConfigErrItem.Hide()
+	m.podsOOMKilledItem.Hide()
 
 	// Clear all pod submenus
 	m.clearPodSubmenus()
@@ -821,14 +1429,28 @@ func (m *Manager) resetMenuState() {
 
 	// Reset icon to unknown state
 	m.updateIcon(models.HealthUnknown)
-	m.currentHealth = models.HealthUnknown
+	m.setPrimaryState(models.HealthUnknown, models.ConnectionOK)
+	m.loginItem.Hide()
 
-	// Clear current status
+	// Clear current status; the next one is not compared with the old context
+	m.statusMu.Lock()
 	m.currentStatus = nil
+	m.notified = healthSnapshot{}
+	m.hookConnection = models.ConnectionOK
+	m.statusMu.Unlock()
+
+	// Acknowledged issues and snoozing until healthy belong to the old context
+	m.muteMu.Lock()
+	m.mute.untilHealthy = false
+	m.mute.clearAcknowledgement()
+	m.muteMu.Unlock()
+	m.updateAlertsMenu()
 
 	// Reset refresh time and data age
 	m.lastRefreshTime = time.Time{}
+	m.nextRetry = time.Time{}
 	m.dataAgeItem.SetTitle("Data Age: Unknown")
+	m.dataAgeItem.SetTooltip(formatTimings(nil))
 }
 
 // showWindowsHelp displays Windows-specific help information in the log/console
@@ -850,68 +1472,87 @@ func (m *Manager) updatePodSubmenus(podStatus *models.PodStatus) {
 	m.clearPodSubmenus()
 
 	// Group pods by state
-	var readyPods, notReadyPods, pendingPods, completedPods, failedPods []models.PodDetail
-
+	pods := make(map[models.PodCategory][]models.PodDetail)
 	for _, pod := range podStatus.Details {
-		switch pod.Phase {
-		case podPhaseRunning:
-			if pod.Ready {
-				readyPods = append(readyPods, pod)
-			} else {
-				notReadyPods = append(notReadyPods, pod)
-			}
-		case podPhasePending:
-			pendingPods = append(pendingPods, pod)
-		case podPhaseSucceeded:
-			completedPods = append(completedPods, pod)
-		case podPhaseFailed:
-			failedPods = append(failedPods, pod)
-		}
+		category := pod.Category()
+		pods[category] = append(pods[category], pod)
 	}
 
-	// Add submenu items for each category
-	m.addPodSubmenuItems(m.podsReadyItem, readyPods, m.podsReadySubmenu)
-	m.addPodSubmenuItems(m.podsNotReadyItem, notReadyPods, m.podsNotReadySubmenu)
-	m.addPodSubmenuItems(m.podsPendingItem, pendingPods, m.podsPendingSubmenu)
-	m.addPodSubmenuItems(m.podsCompletedItem, completedPods, m.podsCompletedSubmenu)
-	m.addPodSubmenuItems(m.podsFailedItem, failedPods, m.podsFailedSubmenu)
+	// Click handlers for pod actions live until the submenus are next cleared
+	actionsCtx := m.newPodActionsContext()
+
+	// Add submenu items for each category; logs are offered where containers have failed
+	m.addPodSubmenuItems(actionsCtx, m.podsReadyItem, pods[models.PodCategoryReady], podStatus.Omitted[models.PodCategoryReady], m.podsReadySubmenu, false)
+	m.addPodSubmenuItems(actionsCtx, m.podsNotReadyItem, pods[models.PodCategoryNotReady], podStatus.Omitted[models.PodCategoryNotReady], m.podsNotReadySubmenu, true)
+	m.addPodSubmenuItems(actionsCtx, m.podsPendingItem, pods[models.PodCategoryPending], podStatus.Omitted[models.PodCategoryPending], m.podsPendingSubmenu, false)
+	m.addPodSubmenuItems(actionsCtx, m.podsCompletedItem, pods[models.PodCategoryCompleted], podStatus.Omitted[models.PodCategoryCompleted], m.podsCompletedSubmenu, false)
+	m.addPodSubmenuItems(actionsCtx, m.podsFailedItem, pods[models.PodCategoryFailed], podStatus.Omitted[models.PodCategoryFailed], m.podsFailedSubmenu, true)
+	m.addPodSubmenuItems(actionsCtx, m.podsCrashLoopItem, pods[models.PodCategoryCrashLoop], podStatus.Omitted[models.PodCategoryCrashLoop], m.podsCrashLoopSubmenu, true)
+	m.addPodSubmenuItems(actionsCtx, m.podsImagePullItem, pods[models.PodCategoryImagePull], podStatus.Omitted[models.PodCategoryImagePull], m.podsImagePullSubmenu, false)
+	m.addPodSubmenuItems(actionsCtx, m.podsConfigErrItem, pods[models.PodCategoryConfigError], podStatus.Omitted[models.PodCategoryConfigError], m.podsConfigErrSubmenu, false)
+	m.addPodSubmenuItems(actionsCtx, m.podsOOMKilledItem, pods[models.PodCategoryOOMKilled], podStatus.Omitted[models.PodCategoryOOMKilled], m.podsOOMKilledSubmenu, true)
+}
+
+// newPodActionsContext returns a context for pod submenu click handlers that
+// is cancelled when the pod submenus are cleared
+func (m *Manager) newPodActionsContext() context.Context {
+	parent := m.mainCtx
+	if parent == nil {
+		parent = context.Background()
+	}
+
+	ctx, cancel := context.WithCancel(parent)
+	m.podActionsCancel = cancel
+	return ctx
 }
 
 // clearPodSubmenus clears all existing pod submenu items
 func (m *Manager) clearPodSubmenus() {
-	// Clear ready pods submenu
-	for _, item := range m.podsReadySubmenu {
-		item.Hide()
+	// Stop click handlers of the items being removed
+	if m.podActionsCancel != nil {
+		m.podActionsCancel()
+		m.podActionsCancel = nil
 	}
+
+	// Clear ready pods submenu
+	removeMenuItems(m.podsReadySubmenu)
 	m.podsReadySubmenu = make(map[string]*systray.MenuItem)
 
 	// Clear not ready pods submenu
-	for _, item := range m.podsNotReadySubmenu {
-		item.Hide()
-	}
+	removeMenuItems(m.podsNotReadySubmenu)
 	m.podsNotReadySubmenu = make(map[string]*systray.MenuItem)
 
 	// Clear pending pods submenu
-	for _, item := range m.podsPendingSubmenu {
-		item.Hide()
-	}
+	removeMenuItems(m.podsPendingSubmenu)
 	m.podsPendingSubmenu = make(map[string]*systray.MenuItem)
 
 	// Clear completed pods submenu
-	for _, item := range m.podsCompletedSubmenu {
-		item.Hide()
-	}
+	removeMenuItems(m.podsCompletedSubmenu)
 	m.podsCompletedSubmenu = make(map[string]*systray.MenuItem)
 
 	// Clear failed pods submenu
-	for _, item := range m.podsFailedSubmenu {
-		item.Hide()
-	}
+	removeMenuItems(m.podsFailedSubmenu)
 	m.podsFailedSubmenu = make(map[string]*systray.MenuItem)
+
+	// Clear container issue submenus
+	for _, submenu := range []map[string]*systray.MenuItem{
+		m.podsCrashLoopSubmenu,
+		m.podsImagePullSubmenu,
+		m.podsConfigErrSubmenu,
+		m.podsOOMKilledSubmenu,
+	} {
+		removeMenuItems(submenu)
+	}
+	m.podsCrashLoopSubmenu = make(map[string]*systray.MenuItem)
+	m.podsImagePullSubmenu = make(map[string]*systray.MenuItem)
+	m.podsConfigErrSubmenu = make(map[string]*systray.MenuItem)
+	m.podsOOMKilledSubmenu = make(map[string]*systray.MenuItem)
 }
 
-// addPodSubmenuItems adds submenu items for pods in a specific state
-func (m *Manager) addPodSubmenuItems(parentItem *systray.MenuItem, pods []models.PodDetail, submenuMap map[string]*systray.MenuItem) {
+// addPodSubmenuItems adds submenu items for pods in a specific state, followed
+// by a count of the pods left out. When withLogs is set and ShowLogs is
+// enabled, each pod gets a "View Logs" action.
+func (m *Manager) addPodSubmenuItems(ctx context.Context, parentItem *systray.MenuItem, pods []models.PodDetail, omitted int, submenuMap map[string]*systray.MenuItem, withLogs bool) {
 	if len(pods) == 0 {
 		return
 	}
@@ -926,17 +1567,486 @@ func (m *Manager) addPodSubmenuItems(parentItem *systray.MenuItem, pods []models
 		// Create tooltip with additional pod information
 		tooltip := fmt.Sprintf("Pod: %s\nNamespace: %s\nPhase: %s\nReady: %t",
 			pod.Name, pod.Namespace, pod.Phase, pod.Ready)
+		if pod.Reason != "" {
+			tooltip += fmt.Sprintf("\nReason: %s", pod.Reason)
+		}
 		if pod.Restarts > 0 {
 			tooltip += fmt.Sprintf("\nRestarts: %d", pod.Restarts)
 		}
+		for _, issue := range pod.Issues {
+			tooltip += fmt.Sprintf("\nContainer %s: %s", issue.Container, issue.Reason)
+		}
 		tooltip += fmt.Sprintf("\nAge: %s", pod.Age.Truncate(time.Second))
 
 		// Add submenu item
 		item := parentItem.AddSubMenuItem(displayName, tooltip)
-		item.Disable() // Make it non-clickable for now, just informational
 
 		// Store in the submenu map using a unique key
 		key := fmt.Sprintf("%s/%s", pod.Namespace, pod.Name)
 		submenuMap[key] = item
+
+		if !withLogs || !m.config.ShowLogs {
+			item.Disable() // Informational only
+			continue
+		}
+
+		// Keep enabled to allow submenu access on macOS
+		logsItem := item.AddSubMenuItem(models.ActionViewLogs.String(),
+			fmt.Sprintf("Open the last %d log lines of each container", m.config.LogTailLines))
+		submenuMap[key+"/logs"] = logsItem
+
+		go func(pod models.PodDetail, menuItem *systray.MenuItem) {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-menuItem.ClickedCh:
+					go m.viewPodLogs(pod)
+				}
+			}
+		}(pod, logsItem)
+	}
+
+	if omitted > 0 {
+		item := parentItem.AddSubMenuItem(formatOmittedTitle(omitted), "Only the pods with the most restarts and the newest pods are listed")
+		item.Disable() // Informational only
+		submenuMap["omitted"] = item
+	}
+}
+
+// updateNodeSubmenu updates the node summary item and lists each node in its submenu
+func (m *Manager) updateNodeSubmenu(nodeStatus *models.NodeStatus) {
+	m.clearNodeSubmenu()
+
+	if nodeStatus == nil {
+		m.nodesMenu.Hide()
+		return
+	}
+
+	m.nodesMenu.SetTitle(formatNodeSummary(nodeStatus))
+	m.nodesMenu.Show()
+
+	for _, node := range nodeStatus.Details {
+		tooltip := fmt.Sprintf("Node: %s\nReady: %t\nKubelet: %s\nAge: %s",
+			node.Name, node.Ready, node.KubeletVersion, node.Age.Truncate(time.Second))
+		if len(node.Roles) > 0 {
+			tooltip += fmt.Sprintf("\nRoles: %s", strings.Join(node.Roles, ", "))
+		}
+		if node.Unschedulable {
+			tooltip += "\nCordoned: scheduling disabled"
+		}
+
+		item := m.nodesMenu.AddSubMenuItem(formatNodeTitle(node), tooltip)
+		item.Disable() // Informational only
+		m.nodeItems[node.Name] = item
+	}
+}
+
+// clearNodeSubmenu clears all existing node submenu items
+func (m *Manager) clearNodeSubmenu() {
+	removeMenuItems(m.nodeItems)
+	m.nodeItems = make(map[string]*systray.MenuItem)
+}
+
+// formatNodeSummary formats the node readiness summary, e.g. "Nodes: 3/4 ready, 1 cordoned"
+func formatNodeSummary(nodeStatus *models.NodeStatus) string {
+	summary := fmt.Sprintf("Nodes: %d/%d ready", nodeStatus.Ready, nodeStatus.Total)
+	if nodeStatus.Cordoned > 0 {
+		summary += fmt.Sprintf(", %d cordoned", nodeStatus.Cordoned)
+	}
+	if nodeStatus.UnderPressure > 0 {
+		summary += fmt.Sprintf(", %d under pressure", nodeStatus.UnderPressure)
+	}
+	return summary
+}
+
+// formatNodeTitle formats a node submenu entry in the style of kubectl get nodes,
+// e.g. "🟡 worker-1 Ready, MemoryPressure (v1.28.3)"
+func formatNodeTitle(node models.NodeDetail) string {
+	icon := "🟢"
+	state := "Ready"
+	if !node.Ready {
+		icon = "🔴"
+		state = "NotReady"
+	}
+
+	states := []string{state}
+	if node.Unschedulable {
+		states = append(states, "SchedulingDisabled")
+		if node.Ready {
+			icon = "⛔"
+		}
+	}
+	if len(node.Conditions) > 0 {
+		states = append(states, node.Conditions...)
+		if node.Ready {
+			icon = "🟡"
+		}
+	}
+
+	return fmt.Sprintf("%s %s %s (%s)", icon, node.Name, strings.Join(states, ", "), node.KubeletVersion)
+}
+
+// updateWorkloadSubmenu updates the workload summary item and lists workloads grouped by kind
+func (m *Manager) updateWorkloadSubmenu(workloads *models.WorkloadStatus) {
+	m.clearWorkloadSubmenu()
+
+	if workloads == nil || workloads.Total == 0 {
+		m.workloadsMenu.Hide()
+		return
+	}
+
+	m.workloadsMenu.SetTitle(formatWorkloadSummary(workloads))
+	m.workloadsMenu.Show()
+
+	// Details are sorted by kind, so each kind's items are added together
+	counts := make(map[string]int)
+	for _, workload := range workloads.Details {
+		counts[workload.Kind]++
+	}
+
+	for _, workload := range workloads.Details {
+		kindItem, exists := m.workloadKindItems[workload.Kind]
+		if !exists {
+			kindItem = m.workloadsMenu.AddSubMenuItem(fmt.Sprintf("%ss (%d)", workload.Kind, counts[workload.Kind]),
+				fmt.Sprintf("%s rollout status", workload.Kind))
+			m.workloadKindItems[workload.Kind] = kindItem
+		}
+
+		tooltip := fmt.Sprintf("%s: %s\nNamespace: %s\nState: %s\nDesired: %d\nUpdated: %d\nReady: %d\nAvailable: %d",
+			workload.Kind, workload.Name, workload.Namespace, workload.State,
+			workload.Desired, workload.Updated, workload.Ready, workload.Available)
+		if workload.Message != "" {
+			tooltip += fmt.Sprintf("\n%s", workload.Message)
+		}
+
+		item := kindItem.AddSubMenuItem(m.formatWorkloadTitle(workload), tooltip)
+		item.Disable() // Informational only
+		m.workloadItems[fmt.Sprintf("%s/%s/%s", workload.Kind, workload.Namespace, workload.Name)] = item
+	}
+}
+
+// clearWorkloadSubmenu clears all existing workload submenu items
+func (m *Manager) clearWorkloadSubmenu() {
+	// Workloads before the kinds they are listed under
+	removeMenuItems(m.workloadItems)
+	removeMenuItems(m.workloadKindItems)
+	m.workloadItems = make(map[string]*systray.MenuItem)
+	m.workloadKindItems = make(map[string]*systray.MenuItem)
+}
+
+// formatWorkloadSummary formats the workload summary, e.g. "Workloads: 12 (1 rolling out, 2 degraded)"
+func formatWorkloadSummary(workloads *models.WorkloadStatus) string {
+	var parts []string
+	if workloads.RollingOut > 0 {
+		parts = append(parts, fmt.Sprintf("%d rolling out", workloads.RollingOut))
+	}
+	if workloads.Degraded > 0 {
+		parts = append(parts, fmt.Sprintf("%d degraded", workloads.Degraded))
+	}
+	if workloads.Failed > 0 {
+		parts = append(parts, fmt.Sprintf("%d failed", workloads.Failed))
+	}
+
+	summary := fmt.Sprintf("Workloads: %d", workloads.Total)
+	if len(parts) > 0 {
+		summary += fmt.Sprintf(" (%s)", strings.Join(parts, ", "))
+	}
+	return summary
+}
+
+// formatWorkloadTitle formats a workload submenu entry, e.g. "🔄 api 3/5 available (rolling out)"
+func (m *Manager) formatWorkloadTitle(workload models.WorkloadDetail) string {
+	name := workload.Name
+	if m.config.Namespace == config.AllNamespaces {
+		name = fmt.Sprintf("%s (%s)", workload.Name, workload.Namespace)
+	}
+
+	title := fmt.Sprintf("%s %d/%d available", name, workload.Available, workload.Desired)
+
+	switch workload.State {
+	case models.WorkloadRollingOut:
+		return fmt.Sprintf("🔄 %s (rolling out)", title)
+	case models.WorkloadDegraded:
+		if workload.Message != "" {
+			return fmt.Sprintf("🟡 %s (%s)", title, workload.Message)
+		}
+		return fmt.Sprintf("🟡 %s (degraded)", title)
+	case models.WorkloadFailed:
+		return fmt.Sprintf("🔴 %s (progress deadline exceeded)", title)
+	default:
+		return fmt.Sprintf("🟢 %s", title)
+	}
+}
+
+// updateJobSubmenu updates the job summary item and lists each CronJob's last
+// outcome followed by active or failed standalone Jobs
+func (m *Manager) updateJobSubmenu(jobs *models.JobStatus) {
+	m.clearJobSubmenu()
+
+	if jobs == nil || (len(jobs.CronJobs) == 0 && len(jobs.Jobs) == 0) {
+		m.jobsMenu.Hide()
+		return
+	}
+
+	m.jobsMenu.SetTitle(formatJobSummary(jobs))
+	m.jobsMenu.Show()
+
+	for _, cronJob := range jobs.CronJobs {
+		tooltip := fmt.Sprintf("CronJob: %s\nNamespace: %s\nSchedule: %s\nActive: %d",
+			cronJob.Name, cronJob.Namespace, cronJob.Schedule, cronJob.Active)
+		if !cronJob.LastScheduleTime.IsZero() {
+			tooltip += fmt.Sprintf("\nLast scheduled: %s", formatAge(time.Since(cronJob.LastScheduleTime)))
+		}
+		if !cronJob.LastSuccessfulTime.IsZero() {
+			tooltip += fmt.Sprintf("\nLast successful: %s", formatAge(time.Since(cronJob.LastSuccessfulTime)))
+		}
+
+		item := m.jobsMenu.AddSubMenuItem(m.formatCronJobTitle(cronJob, time.Now()), tooltip)
+		item.Disable() // Informational only
+		m.jobItems["cronjob/"+cronJob.Namespace+"/"+cronJob.Name] = item
+	}
+
+	for _, job := range jobs.Jobs {
+		// Completed standalone Jobs are not interesting enough to list
+		if job.State == models.JobSucceeded {
+			continue
+		}
+
+		tooltip := fmt.Sprintf("Job: %s\nNamespace: %s\nActive: %d\nSucceeded: %d\nFailed: %d/%d attempts",
+			job.Name, job.Namespace, job.Active, job.Succeeded, job.Failed, job.BackoffLimit+1)
+
+		item := m.jobsMenu.AddSubMenuItem(m.formatJobTitle(job, time.Now()), tooltip)
+		item.Disable() // Informational only
+		m.jobItems["job/"+job.Namespace+"/"+job.Name] = item
+	}
+}
+
+// clearJobSubmenu clears all existing job submenu items
+func (m *Manager) clearJobSubmenu() {
+	removeMenuItems(m.jobItems)
+	m.jobItems = make(map[string]*systray.MenuItem)
+}
+
+// formatJobSummary formats the job summary, e.g. "Jobs: 4 CronJobs (1 failed, 1 missed)"
+func formatJobSummary(jobs *models.JobStatus) string {
+	var parts []string
+	if jobs.Active > 0 {
+		parts = append(parts, fmt.Sprintf("%d active", jobs.Active))
+	}
+	if failed := jobs.FailedCronJobs + jobs.Failed; failed > 0 {
+		parts = append(parts, fmt.Sprintf("%d failed", failed))
+	}
+	if jobs.MissedSchedules > 0 {
+		parts = append(parts, fmt.Sprintf("%d missed", jobs.MissedSchedules))
+	}
+	if jobs.Suspended > 0 {
+		parts = append(parts, fmt.Sprintf("%d suspended", jobs.Suspended))
+	}
+
+	summary := fmt.Sprintf("Jobs: %d CronJobs", len(jobs.CronJobs))
+	if len(parts) > 0 {
+		summary += fmt.Sprintf(" (%s)", strings.Join(parts, ", "))
+	}
+	return summary
+}
+
+// formatCronJobTitle formats a CronJob entry with its last outcome and age,
+// e.g. "❌ backup: failed 15m ago (BackoffLimitExceeded)"
+func (m *Manager) formatCronJobTitle(cronJob models.CronJobDetail, now time.Time) string {
+	name := cronJob.Name
+	if m.config.Namespace == config.AllNamespaces {
+		name = fmt.Sprintf("%s (%s)", cronJob.Name, cronJob.Namespace)
+	}
+
+	switch {
+	case cronJob.Suspended:
+		return fmt.Sprintf("⏸️ %s: suspended", name)
+	case cronJob.LastRun != nil && cronJob.LastRun.State == models.JobRunning:
+		return fmt.Sprintf("🔄 %s: running (started %s)", name, formatAge(now.Sub(cronJob.LastRun.StartTime)))
+	case cronJob.MissedSchedule:
+		if cronJob.LastScheduleTime.IsZero() {
+			return fmt.Sprintf("⚠️ %s: missed schedule (never run)", name)
+		}
+		return fmt.Sprintf("⚠️ %s: missed schedule (last run %s)", name, formatAge(now.Sub(cronJob.LastScheduleTime)))
+	case cronJob.LastRun != nil && cronJob.LastRun.State == models.JobFailed:
+		title := fmt.Sprintf("❌ %s: failed %s", name, formatAge(now.Sub(cronJob.LastRun.CompletionTime)))
+		if cronJob.LastRun.Reason != "" {
+			title += fmt.Sprintf(" (%s)", cronJob.LastRun.Reason)
+		}
+		return title
+	case cronJob.LastRun != nil:
+		return fmt.Sprintf("✅ %s: succeeded %s", name, formatAge(now.Sub(cronJob.LastRun.CompletionTime)))
+	case !cronJob.LastSuccessfulTime.IsZero():
+		// Finished runs may already have been cleaned up by the history limit
+		return fmt.Sprintf("✅ %s: succeeded %s", name, formatAge(now.Sub(cronJob.LastSuccessfulTime)))
+	default:
+		return fmt.Sprintf("⏱️ %s: not run yet", name)
+	}
+}
+
+// formatJobTitle formats a standalone Job entry, e.g. "🔄 migrate: running (started 2m ago)"
+func (m *Manager) formatJobTitle(job models.JobDetail, now time.Time) string {
+	name := job.Name
+	if m.config.Namespace == config.AllNamespaces {
+		name = fmt.Sprintf("%s (%s)", job.Name, job.Namespace)
+	}
+
+	switch job.State {
+	case models.JobFailed:
+		title := fmt.Sprintf("❌ %s: failed %s", name, formatAge(now.Sub(job.CompletionTime)))
+		if job.Reason != "" {
+			title += fmt.Sprintf(" (%s)", job.Reason)
+		}
+		return title
+	case models.JobSucceeded:
+		return fmt.Sprintf("✅ %s: succeeded %s", name, formatAge(now.Sub(job.CompletionTime)))
+	default:
+		return fmt.Sprintf("🔄 %s: running (started %s)", name, formatAge(now.Sub(job.StartTime)))
+	}
+}
+
+// updateEventSubmenu lists the most recent Warning events, newest first
+func (m *Manager) updateEventSubmenu(events []models.Event) {
+	m.clearEventSubmenu()
+
+	if !m.config.ShowEvents || len(events) == 0 {
+		m.eventsMenu.Hide()
+		return
+	}
+
+	m.eventsMenu.SetTitle(fmt.Sprintf("Recent Events: %d warnings", len(events)))
+	m.eventsMenu.Show()
+
+	for i, event := range events {
+		if i >= maxEventsDisplayed {
+			break
+		}
+
+		tooltip := fmt.Sprintf("%s: %s\nNamespace: %s\nReason: %s\nCount: %d\n%s",
+			event.Kind, event.Object, event.Namespace, event.Reason, event.Count, event.Message)
+
+		item := m.eventsMenu.AddSubMenuItem(m.formatEventTitle(event, time.Now()), tooltip)
+		item.Disable() // Informational only
+		m.eventItems[fmt.Sprintf("%s/%s/%s/%s", event.Namespace, event.Kind, event.Object, event.Reason)] = item
+	}
+}
+
+// clearEventSubmenu clears all existing event submenu items
+func (m *Manager) clearEventSubmenu() {
+	removeMenuItems(m.eventItems)
+	m.eventItems = make(map[string]*systray.MenuItem)
+}
+
+// formatEventTitle formats an event entry, e.g. "⚠️ BackOff: Pod/api-7f9 ×12 (3m ago)"
+func (m *Manager) formatEventTitle(event models.Event, now time.Time) string {
+	object := event.Object
+	if event.Kind != "" {
+		object = fmt.Sprintf("%s/%s", event.Kind, event.Object)
+	}
+	if m.config.Namespace == config.AllNamespaces && event.Namespace != "" {
+		object = fmt.Sprintf("%s (%s)", object, event.Namespace)
+	}
+
+	title := fmt.Sprintf("⚠️ %s: %s", event.Reason, object)
+	if event.Count > 1 {
+		title += fmt.Sprintf(" ×%d", event.Count)
+	}
+	return fmt.Sprintf("%s (%s)", title, formatAge(now.Sub(event.Timestamp)))
+}
+
+// updatePermissionsSubmenu lists the features disabled by the user's permissions
+// and the namespaces monitored instead of all namespaces
+func (m *Manager) updatePermissionsSubmenu(perms *models.Permissions) {
+	m.clearPermissionsSubmenu()
+
+	if !perms.Degraded() {
+		m.permissionsMenu.Hide()
+		return
+	}
+
+	m.permissionsMenu.SetTitle(formatPermissionsSummary(perms))
+	m.permissionsMenu.Show()
+
+	for _, feature := range models.AllFeatures {
+		reason, denied := perms.Denied[feature]
+		if !denied {
+			continue
+		}
+		item := m.permissionsMenu.AddSubMenuItem(formatPermissionTitle(feature, reason), "Disabled: "+reason)
+		item.Disable() // Informational only
+		m.permissionItems[string(feature)] = item
+	}
+
+	if len(perms.Namespaces) > 0 {
+		item := m.permissionsMenu.AddSubMenuItem(
+			"📂 Namespaces: "+strings.Join(perms.Namespaces, ", "),
+			"Monitored instead of all namespaces; set fallback_namespaces to change")
+		item.Disable() // Informational only
+		m.permissionItems["namespaces"] = item
+	}
+}
+
+// clearPermissionsSubmenu clears all existing permission submenu items
+func (m *Manager) clearPermissionsSubmenu() {
+	removeMenuItems(m.permissionItems)
+	m.permissionItems = make(map[string]*systray.MenuItem)
+}
+
+// formatPermissionsSummary formats the permissions menu title, e.g. "Permissions: 2 features disabled"
+func formatPermissionsSummary(perms *models.Permissions) string {
+	switch len(perms.Denied) {
+	case 0:
+		return "Permissions: Limited namespaces"
+	case 1:
+		return "Permissions: 1 feature disabled"
+	default:
+		return fmt.Sprintf("Permissions: %d features disabled", len(perms.Denied))
+	}
+}
+
+// formatPermissionTitle formats a disabled feature, e.g. "🚫 Nodes: cannot list nodes"
+func formatPermissionTitle(feature models.Feature, reason string) string {
+	return fmt.Sprintf("🚫 %s: %s", feature, reason)
+}
+
+// formatOmittedTitle formats the item counting pods left out of a submenu, e.g. "… and 12 more"
+func formatOmittedTitle(omitted int) string {
+	return fmt.Sprintf("… and %d more", omitted)
+}
+
+// formatTimings formats refresh phase durations, slowest first, e.g.
+// "Refresh took 420ms: pods 380ms, nodes 40ms"
+func formatTimings(timings map[string]time.Duration) string {
+	total, ok := timings["total"]
+	if !ok {
+		return "Time since last successful refresh"
+	}
+
+	phases := make([]string, 0, len(timings))
+	for phase := range timings {
+		if phase != "total" {
+			phases = append(phases, phase)
+		}
+	}
+	sort.Slice(phases, func(i, j int) bool {
+		if timings[phases[i]] != timings[phases[j]] {
+			return timings[phases[i]] > timings[phases[j]]
+		}
+		return phases[i] < phases[j]
+	})
+
+	parts := make([]string, len(phases))
+	for i, phase := range phases {
+		parts[i] = fmt.Sprintf("%s %s", phase, formatPhaseDuration(timings[phase]))
+	}
+	return fmt.Sprintf("Refresh took %s: %s", formatPhaseDuration(total), strings.Join(parts, ", "))
+}
+
+// formatPhaseDuration rounds a phase duration to milliseconds
+func formatPhaseDuration(d time.Duration) string {
+	if d < time.Millisecond {
+		return "<1ms"
 	}
+	return d.Round(time.Millisecond).String()
 }
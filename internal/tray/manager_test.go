@@ -1,9 +1,13 @@
 package tray
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/mattlqx/k8s-tray/internal/config"
 	"github.com/mattlqx/k8s-tray/pkg/models"
 )
 
@@ -268,3 +272,392 @@ func TestPodSubmenuWithNoPods(t *testing.T) {
 		t.Errorf("Expected 0 failed pods, got %d", len(failedPods))
 	}
 }
+
+func TestFormatResourceStat(t *testing.T) {
+	stat := &models.ResourceStat{
+		Used:                2.0,
+		Requested:           3.0,
+		Limits:              6.0,
+		Available:           8.0,
+		Percentage:          25.0,
+		RequestedPercentage: 37.5,
+		LimitsPercentage:    75.0,
+	}
+
+	tests := []struct {
+		name             string
+		metricsAvailable bool
+		expected         string
+	}{
+		{"With metrics-server", true, "CPU: 2.0/8.0 cores (25.0% used, 37.5% requested)"},
+		{"Without metrics-server", false, "CPU: 3.0/8.0 cores requested (37.5%)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := formatResourceStat("CPU", "cores", stat, tt.metricsAvailable)
+			if result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestFormatNodeTitle(t *testing.T) {
+	tests := []struct {
+		name     string
+		node     models.NodeDetail
+		expected string
+	}{
+		{
+			name:     "Ready node",
+			node:     models.NodeDetail{Name: "worker-1", Ready: true, KubeletVersion: "v1.28.3"},
+			expected: "🟢 worker-1 Ready (v1.28.3)",
+		},
+		{
+			name:     "Not ready node",
+			node:     models.NodeDetail{Name: "worker-2", Ready: false, KubeletVersion: "v1.28.3"},
+			expected: "🔴 worker-2 NotReady (v1.28.3)",
+		},
+		{
+			name:     "Node under pressure",
+			node:     models.NodeDetail{Name: "worker-3", Ready: true, Conditions: []string{"MemoryPressure"}, KubeletVersion: "v1.28.3"},
+			expected: "🟡 worker-3 Ready, MemoryPressure (v1.28.3)",
+		},
+		{
+			name:     "Cordoned node",
+			node:     models.NodeDetail{Name: "worker-4", Ready: true, Unschedulable: true, KubeletVersion: "v1.27.9"},
+			expected: "⛔ worker-4 Ready, SchedulingDisabled (v1.27.9)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := formatNodeTitle(tt.node)
+			if result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestFormatNodeSummary(t *testing.T) {
+	tests := []struct {
+		name       string
+		nodeStatus *models.NodeStatus
+		expected   string
+	}{
+		{
+			name:       "All ready",
+			nodeStatus: &models.NodeStatus{Total: 3, Ready: 3},
+			expected:   "Nodes: 3/3 ready",
+		},
+		{
+			name:       "Cordoned and under pressure",
+			nodeStatus: &models.NodeStatus{Total: 4, Ready: 3, NotReady: 1, Cordoned: 1, MemoryPressure: 1, UnderPressure: 1},
+			expected:   "Nodes: 3/4 ready, 1 cordoned, 1 under pressure",
+		},
+		{
+			name:       "One node with several pressures",
+			nodeStatus: &models.NodeStatus{Total: 2, Ready: 2, MemoryPressure: 1, DiskPressure: 1, PIDPressure: 1, UnderPressure: 1},
+			expected:   "Nodes: 2/2 ready, 1 under pressure",
+		},
+		{
+			name:       "Network unavailable is not pressure",
+			nodeStatus: &models.NodeStatus{Total: 2, Ready: 2, NetworkUnavailable: 1},
+			expected:   "Nodes: 2/2 ready",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := formatNodeSummary(tt.nodeStatus); result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestFormatWorkloadTitle(t *testing.T) {
+	m := &Manager{config: &config.Config{Namespace: "default"}}
+
+	tests := []struct {
+		name     string
+		workload models.WorkloadDetail
+		expected string
+	}{
+		{
+			name:     "Healthy deployment",
+			workload: models.WorkloadDetail{Name: "api", Desired: 5, Available: 5, State: models.WorkloadHealthy},
+			expected: "🟢 api 5/5 available",
+		},
+		{
+			name:     "Rolling out",
+			workload: models.WorkloadDetail{Name: "api", Desired: 5, Available: 3, State: models.WorkloadRollingOut},
+			expected: "🔄 api 3/5 available (rolling out)",
+		},
+		{
+			name:     "Degraded daemon set",
+			workload: models.WorkloadDetail{Name: "agent", Desired: 6, Available: 3, State: models.WorkloadDegraded, Message: "missing on 3 node(s)"},
+			expected: "🟡 agent 3/6 available (missing on 3 node(s))",
+		},
+		{
+			name:     "Stalled rollout",
+			workload: models.WorkloadDetail{Name: "web", Desired: 2, Available: 1, State: models.WorkloadFailed},
+			expected: "🔴 web 1/2 available (progress deadline exceeded)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := m.formatWorkloadTitle(tt.workload)
+			if result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestFormatCronJobTitle(t *testing.T) {
+	m := &Manager{config: &config.Config{Namespace: "default"}}
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		cronJob  models.CronJobDetail
+		expected string
+	}{
+		{
+			name:     "Suspended",
+			cronJob:  models.CronJobDetail{Name: "report", Suspended: true},
+			expected: "⏸️ report: suspended",
+		},
+		{
+			name: "Last run succeeded",
+			cronJob: models.CronJobDetail{Name: "backup", LastRun: &models.JobDetail{
+				State: models.JobSucceeded, CompletionTime: now.Add(-2 * time.Hour),
+			}},
+			expected: "✅ backup: succeeded 2.0h ago",
+		},
+		{
+			name: "Last run failed",
+			cronJob: models.CronJobDetail{Name: "backup", LastRun: &models.JobDetail{
+				State: models.JobFailed, Reason: "BackoffLimitExceeded", CompletionTime: now.Add(-15 * time.Minute),
+			}},
+			expected: "❌ backup: failed 15m ago (BackoffLimitExceeded)",
+		},
+		{
+			name:     "Missed schedule",
+			cronJob:  models.CronJobDetail{Name: "sync", MissedSchedule: true, LastScheduleTime: now.Add(-3 * time.Hour)},
+			expected: "⚠️ sync: missed schedule (last run 3.0h ago)",
+		},
+		{
+			name: "Running",
+			cronJob: models.CronJobDetail{Name: "etl", Active: 1, LastRun: &models.JobDetail{
+				State: models.JobRunning, StartTime: now.Add(-5 * time.Minute),
+			}},
+			expected: "🔄 etl: running (started 5m ago)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := m.formatCronJobTitle(tt.cronJob, now)
+			if result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestFormatEventTitle(t *testing.T) {
+	now := time.Now()
+	event := models.Event{
+		Reason:    "BackOff",
+		Kind:      "Pod",
+		Object:    "api-7f9",
+		Namespace: "prod",
+		Count:     12,
+		Timestamp: now.Add(-3 * time.Minute),
+	}
+
+	tests := []struct {
+		name      string
+		namespace string
+		event     models.Event
+		expected  string
+	}{
+		{
+			name:      "Repeated event",
+			namespace: "prod",
+			event:     event,
+			expected:  "⚠️ BackOff: Pod/api-7f9 ×12 (3m ago)",
+		},
+		{
+			name:      "All namespaces shows namespace",
+			namespace: config.AllNamespaces,
+			event:     event,
+			expected:  "⚠️ BackOff: Pod/api-7f9 (prod) ×12 (3m ago)",
+		},
+		{
+			name:      "Single occurrence omits count",
+			namespace: "prod",
+			event: models.Event{
+				Reason: "FailedScheduling", Kind: "Pod", Object: "worker-0", Count: 1, Timestamp: now.Add(-30 * time.Second),
+			},
+			expected: "⚠️ FailedScheduling: Pod/worker-0 (30s ago)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Manager{config: &config.Config{Namespace: tt.namespace}}
+			result := m.formatEventTitle(tt.event, now)
+			if result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestFormatContextTitle(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   models.KubeContext
+		showFile bool
+		expected string
+	}{
+		{
+			name:     "Single kubeconfig",
+			source:   models.KubeContext{Name: "dev", Source: "/home/me/.kube/config"},
+			expected: "dev",
+		},
+		{
+			name:     "Merged kubeconfigs show file",
+			source:   models.KubeContext{Name: "prod", Source: "/home/me/.kube/configs/eks.yaml"},
+			showFile: true,
+			expected: "prod (eks.yaml)",
+		},
+		{
+			name: "Duplicate name",
+			source: models.KubeContext{
+				Name: "prod", Source: "/home/me/.kube/config", Shadowed: []string{"/home/me/.kube/configs/eks.yaml"},
+			},
+			showFile: true,
+			expected: "prod (config) ⚠️",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := formatContextTitle(tt.source.Name, tt.source, tt.showFile)
+			if result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestFormatConnectionStatus(t *testing.T) {
+	expected := "Status: Unreachable - Check your network or VPN connection"
+	if result := formatConnectionStatus(models.ConnectionUnreachable); result != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, result)
+	}
+}
+
+func TestFormatPermissionsSummary(t *testing.T) {
+	tests := []struct {
+		name     string
+		perms    *models.Permissions
+		expected string
+	}{
+		{
+			name:     "Namespaces only",
+			perms:    &models.Permissions{Namespaces: []string{"team-a"}},
+			expected: "Permissions: Limited namespaces",
+		},
+		{
+			name:     "One feature",
+			perms:    &models.Permissions{Denied: map[models.Feature]string{models.FeatureNodes: "cannot list nodes"}},
+			expected: "Permissions: 1 feature disabled",
+		},
+		{
+			name: "Several features",
+			perms: &models.Permissions{Denied: map[models.Feature]string{
+				models.FeatureNodes:     "cannot list nodes",
+				models.FeatureResources: "cannot list nodes",
+			}},
+			expected: "Permissions: 2 features disabled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := formatPermissionsSummary(tt.perms); result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+
+	expected := "🚫 Nodes: cannot list nodes"
+	if result := formatPermissionTitle(models.FeatureNodes, "cannot list nodes"); result != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, result)
+	}
+}
+
+func TestFormatOmittedTitle(t *testing.T) {
+	expected := "… and 12 more"
+	if result := formatOmittedTitle(12); result != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, result)
+	}
+}
+
+func TestFormatTimings(t *testing.T) {
+	tests := []struct {
+		name     string
+		timings  map[string]time.Duration
+		expected string
+	}{
+		{
+			name:     "No timings",
+			timings:  nil,
+			expected: "Time since last successful refresh",
+		},
+		{
+			name: "Slowest first",
+			timings: map[string]time.Duration{
+				"total":   420 * time.Millisecond,
+				"nodes":   40 * time.Millisecond,
+				"pods":    380*time.Millisecond + 400*time.Microsecond,
+				"context": 200 * time.Nanosecond,
+			},
+			expected: "Refresh took 420ms: pods 380ms, nodes 40ms, context <1ms",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := formatTimings(tt.timings); result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestRefreshStatus_Cancelled(t *testing.T) {
+	m := &Manager{
+		config:    &config.Config{},
+		k8sClient: &fakeSource{contextName: "prod", statusErr: context.Canceled},
+	}
+	m.currentStatus = testStatus(models.HealthHealthy)
+
+	// Monitoring restarted while the refresh was in flight
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := m.refreshStatus(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the refresh to be cancelled, got %v", err)
+	}
+	if m.notified.known || m.currentStatus == nil {
+		t.Error("Expected a cancelled refresh not to be recorded as a failure")
+	}
+}
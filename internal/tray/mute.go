@@ -0,0 +1,323 @@
+package tray
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"fyne.io/systray"
+	"github.com/mattlqx/k8s-tray/internal/config"
+	"github.com/mattlqx/k8s-tray/pkg/models"
+	"github.com/robfig/cron/v3"
+)
+
+// Snooze durations offered in the Alerts menu
+const (
+	shortSnooze = 15 * time.Minute
+	longSnooze  = time.Hour
+)
+
+// muteReason is why alerts are muted
+type muteReason int
+
+const (
+	muteNone muteReason = iota
+	muteMaintenance
+	muteSnoozed
+	muteUntilHealthy
+	muteAcknowledged
+)
+
+// muteStatus is whether, why and until when alerts are muted
+type muteStatus struct {
+	reason muteReason
+	until  time.Time // End of the maintenance window or snooze
+	pods   int       // Failing pods acknowledged
+}
+
+// muteState records how the user muted alerts about the current context
+type muteState struct {
+	context      string    // Context whose maintenance windows apply
+	snoozedUntil time.Time // Zero when not snoozed for a duration
+	untilHealthy bool      // Snoozed until the cluster is healthy again
+
+	// Failing pods when the user acknowledged the current issues: those listed
+	// in the status, sorted, and the number left out of its capped details,
+	// and the cluster health then
+	acknowledged        bool
+	acknowledgedPods    []string
+	acknowledgedOmitted int
+	acknowledgedHealth  models.HealthStatus
+}
+
+// status returns whether alerts are muted at now; maintenance windows take
+// precedence over snoozes, which take precedence over acknowledgements
+func (s *muteState) status(now time.Time, windows []config.MaintenanceWindow) muteStatus {
+	if end, ok := activeMaintenance(windows, now); ok {
+		return muteStatus{reason: muteMaintenance, until: end}
+	}
+	if now.Before(s.snoozedUntil) {
+		return muteStatus{reason: muteSnoozed, until: s.snoozedUntil}
+	}
+	if s.untilHealthy {
+		return muteStatus{reason: muteUntilHealthy}
+	}
+	if s.acknowledged {
+		return muteStatus{reason: muteAcknowledged, pods: len(s.acknowledgedPods) + s.acknowledgedOmitted}
+	}
+	return muteStatus{}
+}
+
+// observe ends the mutes a refreshed status resolves: snoozing until healthy
+// ends once healthy, an acknowledgement once the set of failing pods or the
+// health changes, so that node, workload and job problems are not muted.
+// Failing pods left out of the capped details are only compared by number.
+func (s *muteState) observe(status *models.ClusterStatus) {
+	if s.untilHealthy && status.HealthStatus == models.HealthHealthy {
+		log.Printf("Cluster is healthy, resuming alerts")
+		s.untilHealthy = false
+	}
+
+	if !s.acknowledged {
+		return
+	}
+	if pods, omitted := failingPods(status); !equalStrings(s.acknowledgedPods, pods) || s.acknowledgedOmitted != omitted {
+		log.Printf("Failing pods changed since the issues were acknowledged, resuming alerts")
+		s.clearAcknowledgement()
+	} else if status.HealthStatus != s.acknowledgedHealth {
+		log.Printf("Health changed since the issues were acknowledged, resuming alerts")
+		s.clearAcknowledgement()
+	}
+}
+
+// observeFailure ends an acknowledgement when a refresh failed; losing the
+// connection is not one of the acknowledged issues
+func (s *muteState) observeFailure() {
+	if s.acknowledged {
+		log.Printf("Refresh failed since the issues were acknowledged, resuming alerts")
+		s.clearAcknowledgement()
+	}
+}
+
+// acknowledge mutes alerts until the set of failing pods in status or its health changes
+func (s *muteState) acknowledge(status *models.ClusterStatus) {
+	s.acknowledged = true
+	s.acknowledgedPods, s.acknowledgedOmitted = failingPods(status)
+	s.acknowledgedHealth = models.HealthUnknown
+	if status != nil {
+		s.acknowledgedHealth = status.HealthStatus
+	}
+}
+
+// resume unmutes alerts, except for maintenance windows
+func (s *muteState) resume() {
+	s.snoozedUntil = time.Time{}
+	s.untilHealthy = false
+	s.clearAcknowledgement()
+}
+
+// clearAcknowledgement ends the acknowledgement of the current issues
+func (s *muteState) clearAcknowledgement() {
+	s.acknowledged, s.acknowledgedPods, s.acknowledgedOmitted, s.acknowledgedHealth = false, nil, 0, models.HealthUnknown
+}
+
+// failingPods returns the namespace/name of the pods listed in status that
+// make the cluster unhealthy, sorted, and the number of those left out of
+// its capped details
+func failingPods(status *models.ClusterStatus) ([]string, int) {
+	if status == nil || status.PodStatus == nil {
+		return nil, 0
+	}
+
+	var pods []string
+	for _, pod := range status.PodStatus.Details {
+		if pod.Category().Severity() != models.HealthHealthy {
+			pods = append(pods, pod.Namespace+"/"+pod.Name)
+		}
+	}
+	sort.Strings(pods)
+
+	omitted := 0
+	for category, count := range status.PodStatus.Omitted {
+		if category.Severity() != models.HealthHealthy {
+			omitted += count
+		}
+	}
+	return pods, omitted
+}
+
+// equalStrings reports whether two slices hold the same strings in the same order
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// activeMaintenance returns the end of the maintenance window now falls in.
+// A window is active when its next start after now minus its duration is not
+// after now.
+func activeMaintenance(windows []config.MaintenanceWindow, now time.Time) (time.Time, bool) {
+	var end time.Time
+	for _, window := range windows {
+		schedule, err := cron.ParseStandard(window.Schedule)
+		if err != nil || window.Duration <= 0 {
+			continue
+		}
+
+		start := schedule.Next(now.Add(-window.Duration))
+		if !start.After(now) && start.Add(window.Duration).After(end) {
+			end = start.Add(window.Duration)
+		}
+	}
+	return end, !end.IsZero()
+}
+
+// validateMaintenanceWindows logs the maintenance windows that will be ignored
+func validateMaintenanceWindows(cfg *config.Config) {
+	for contextName, settings := range cfg.Contexts {
+		for _, window := range settings.MaintenanceWindows {
+			if _, err := cron.ParseStandard(window.Schedule); err != nil {
+				log.Printf("Warning: ignoring maintenance window %q of context %s: %v", window.Schedule, contextName, err)
+			} else if window.Duration <= 0 {
+				log.Printf("Warning: ignoring maintenance window %q of context %s: no duration", window.Schedule, contextName)
+			}
+		}
+	}
+}
+
+// formatAlertsTitle formats the Alerts menu, e.g. "🔕 Alerts: Snoozed until 14:30"
+func formatAlertsTitle(status muteStatus, now time.Time) string {
+	switch status.reason {
+	case muteMaintenance:
+		return "🔕 Alerts: Maintenance until " + formatUntil(status.until, now)
+	case muteSnoozed:
+		return "🔕 Alerts: Snoozed until " + formatUntil(status.until, now)
+	case muteUntilHealthy:
+		return "🔕 Alerts: Snoozed until healthy"
+	case muteAcknowledged:
+		if status.pods == 1 {
+			return "🔕 Alerts: Acknowledged (1 failing pod)"
+		}
+		return fmt.Sprintf("🔕 Alerts: Acknowledged (%d failing pods)", status.pods)
+	default:
+		return "🔔 Alerts: On"
+	}
+}
+
+// formatUntil formats the end of a mute, with the weekday when it is not today
+func formatUntil(until, now time.Time) string {
+	y1, m1, d1 := until.Date()
+	y2, m2, d2 := now.Date()
+	if y1 == y2 && m1 == m2 && d1 == d2 {
+		return until.Format("15:04")
+	}
+	return until.Format("Mon 15:04")
+}
+
+// buildAlertsMenu adds the Alerts submenu with snooze and acknowledge actions
+func (m *Manager) buildAlertsMenu() {
+	m.alertsMenu = systray.AddMenuItem("🔔 Alerts: On", "Snooze or acknowledge alerts")
+	m.snoozeShortItem = m.alertsMenu.AddSubMenuItem("Snooze for 15m", "Mute alerts for 15 minutes")
+	m.snoozeLongItem = m.alertsMenu.AddSubMenuItem("Snooze for 1h", "Mute alerts for an hour")
+	m.snoozeHealthyItem = m.alertsMenu.AddSubMenuItem("Snooze until healthy", "Mute alerts until the cluster is healthy again")
+	m.acknowledgeItem = m.alertsMenu.AddSubMenuItem("Acknowledge current issues", "Mute alerts until the failing pods or the cluster health change")
+	m.resumeItem = m.alertsMenu.AddSubMenuItem("Resume alerts", "End the snooze or acknowledgement")
+	m.resumeItem.Hide()
+}
+
+// currentMute returns whether alerts about the current context are muted
+func (m *Manager) currentMute() muteStatus {
+	m.muteMu.Lock()
+	defer m.muteMu.Unlock()
+	return m.mute.status(time.Now(), m.config.ContextSettings(m.mute.context).MaintenanceWindows)
+}
+
+// observeMute records the context a refresh was for and ends the mutes its
+// status resolves; status is nil when the refresh failed
+func (m *Manager) observeMute(contextName string, status *models.ClusterStatus) {
+	m.muteMu.Lock()
+	m.mute.context = contextName
+	if status != nil {
+		m.mute.observe(status)
+	} else {
+		m.mute.observeFailure()
+	}
+	m.muteMu.Unlock()
+
+	m.updateAlertsMenu()
+}
+
+// snooze mutes alerts for a duration
+func (m *Manager) snooze(duration time.Duration) {
+	m.muteMu.Lock()
+	m.mute.snoozedUntil = time.Now().Add(duration)
+	m.muteMu.Unlock()
+
+	log.Printf("Snoozed alerts for %s", duration)
+	m.updateAlertsMenu()
+}
+
+// snoozeUntilHealthy mutes alerts until the cluster is healthy again
+func (m *Manager) snoozeUntilHealthy() {
+	m.muteMu.Lock()
+	m.mute.untilHealthy = true
+	m.muteMu.Unlock()
+
+	log.Printf("Snoozed alerts until the cluster is healthy")
+	m.updateAlertsMenu()
+}
+
+// acknowledgeIssues mutes alerts until the set of failing pods or the health changes
+func (m *Manager) acknowledgeIssues() {
+	m.statusMu.Lock()
+	m.muteMu.Lock()
+	m.mute.acknowledge(m.currentStatus)
+	pods := len(m.mute.acknowledgedPods) + m.mute.acknowledgedOmitted
+	m.muteMu.Unlock()
+	m.statusMu.Unlock()
+
+	log.Printf("Acknowledged issues of %d failing pods", pods)
+	m.updateAlertsMenu()
+}
+
+// resumeAlerts ends snoozes and acknowledgements
+func (m *Manager) resumeAlerts() {
+	m.muteMu.Lock()
+	m.mute.resume()
+	m.muteMu.Unlock()
+
+	log.Printf("Resumed alerts")
+	m.updateAlertsMenu()
+}
+
+// updateAlertsMenu shows whether alerts are muted, switching the icon to or
+// from its muted variant when that changed
+func (m *Manager) updateAlertsMenu() {
+	if m.alertsMenu == nil {
+		return
+	}
+
+	m.iconMu.Lock()
+	defer m.iconMu.Unlock()
+
+	status := m.currentMute()
+	m.alertsMenu.SetTitle(formatAlertsTitle(status, time.Now()))
+
+	// Maintenance windows cannot be ended early
+	if status.reason == muteNone || status.reason == muteMaintenance {
+		m.resumeItem.Hide()
+	} else {
+		m.resumeItem.Show()
+	}
+
+	if m.mutedIcon(time.Now()) != m.iconMuted {
+		m.drawIcon()
+	}
+}
@@ -0,0 +1,160 @@
+package tray
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattlqx/k8s-tray/internal/config"
+	"github.com/mattlqx/k8s-tray/pkg/models"
+)
+
+func TestActiveMaintenance(t *testing.T) {
+	// Saturdays from 02:00 to 04:00, and the first day of each month from 22:00 to 01:00
+	windows := []config.MaintenanceWindow{
+		{Schedule: "0 2 * * SAT", Duration: 2 * time.Hour},
+		{Schedule: "0 22 1 * *", Duration: 3 * time.Hour},
+		{Schedule: "not a schedule", Duration: time.Hour},
+		{Schedule: "* * * * *"}, // No duration
+	}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		expected time.Time // Zero when outside every window
+	}{
+		{name: "Before the window", now: time.Date(2024, 6, 8, 1, 59, 0, 0, time.Local)},
+		{name: "At the start", now: time.Date(2024, 6, 8, 2, 0, 0, 0, time.Local), expected: time.Date(2024, 6, 8, 4, 0, 0, 0, time.Local)},
+		{name: "During the window", now: time.Date(2024, 6, 8, 3, 30, 0, 0, time.Local), expected: time.Date(2024, 6, 8, 4, 0, 0, 0, time.Local)},
+		{name: "At the end", now: time.Date(2024, 6, 8, 4, 0, 0, 0, time.Local)},
+		{name: "Other weekday", now: time.Date(2024, 6, 9, 3, 0, 0, 0, time.Local)},
+		{name: "Past midnight", now: time.Date(2024, 6, 2, 0, 30, 0, 0, time.Local), expected: time.Date(2024, 6, 2, 1, 0, 0, 0, time.Local)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			end, ok := activeMaintenance(windows, tt.now)
+			if ok != !tt.expected.IsZero() || !end.Equal(tt.expected) {
+				t.Errorf("Expected %v, got %v (active %v)", tt.expected, end, ok)
+			}
+		})
+	}
+}
+
+func TestMuteState(t *testing.T) {
+	now := time.Date(2024, 6, 8, 12, 0, 0, 0, time.Local)
+	crashing := models.PodDetail{Name: "api", Namespace: "default", Phase: "Running", Issue: models.PodIssueCrashLoop}
+	pending := models.PodDetail{Name: "worker", Namespace: "default", Phase: "Pending"}
+	ready := models.PodDetail{Name: "web", Namespace: "default", Phase: "Running", Ready: true}
+
+	t.Run("Snooze expires", func(t *testing.T) {
+		state := muteState{snoozedUntil: now.Add(shortSnooze)}
+		if status := state.status(now, nil); status.reason != muteSnoozed || !status.until.Equal(now.Add(shortSnooze)) {
+			t.Errorf("Expected snoozed until %v, got %+v", now.Add(shortSnooze), status)
+		}
+		if status := state.status(now.Add(shortSnooze), nil); status.reason != muteNone {
+			t.Errorf("Expected the snooze to have expired, got %+v", status)
+		}
+	})
+
+	t.Run("Snooze until healthy", func(t *testing.T) {
+		state := muteState{untilHealthy: true}
+		state.observe(testStatus(models.HealthWarning, pending))
+		if state.status(now, nil).reason != muteUntilHealthy {
+			t.Error("Expected alerts to stay muted while unhealthy")
+		}
+		state.observe(testStatus(models.HealthHealthy, ready))
+		if state.status(now, nil).reason != muteNone {
+			t.Error("Expected alerts to resume once healthy")
+		}
+	})
+
+	t.Run("Acknowledged until failing pods change", func(t *testing.T) {
+		state := muteState{}
+		state.acknowledge(testStatus(models.HealthCritical, crashing, ready))
+
+		// Same failing pods, even with other pods coming and going
+		state.observe(testStatus(models.HealthCritical, ready, crashing))
+		state.observe(testStatus(models.HealthCritical, crashing))
+		if status := state.status(now, nil); status.reason != muteAcknowledged || status.pods != 1 {
+			t.Errorf("Expected 1 acknowledged pod, got %+v", status)
+		}
+
+		state.observe(testStatus(models.HealthCritical, crashing, pending))
+		if state.status(now, nil).reason != muteNone {
+			t.Error("Expected alerts to resume when another pod fails")
+		}
+	})
+
+	t.Run("Acknowledged until health changes otherwise", func(t *testing.T) {
+		state := muteState{}
+		state.acknowledge(testStatus(models.HealthWarning, pending))
+
+		// A node going down makes the cluster critical with the same failing pods
+		state.observe(testStatus(models.HealthCritical, pending))
+		if state.status(now, nil).reason != muteNone {
+			t.Error("Expected alerts to resume when the health changes")
+		}
+
+		state.acknowledge(testStatus(models.HealthWarning, pending))
+		state.observeFailure()
+		if state.status(now, nil).reason != muteNone {
+			t.Error("Expected alerts to resume when the cluster cannot be reached")
+		}
+	})
+
+	t.Run("Acknowledged with capped details", func(t *testing.T) {
+		capped := func(omitted int) *models.ClusterStatus {
+			status := testStatus(models.HealthCritical, crashing)
+			status.PodStatus.Omitted = map[models.PodCategory]int{models.PodCategoryCrashLoop: omitted, models.PodCategoryReady: 4}
+			return status
+		}
+
+		state := muteState{}
+		state.acknowledge(capped(2))
+		if status := state.status(now, nil); status.pods != 3 {
+			t.Errorf("Expected 3 acknowledged pods, got %+v", status)
+		}
+
+		state.observe(capped(3))
+		if state.status(now, nil).reason != muteNone {
+			t.Error("Expected alerts to resume when another pod left out of the details fails")
+		}
+	})
+
+	t.Run("Maintenance takes precedence", func(t *testing.T) {
+		state := muteState{snoozedUntil: now.Add(longSnooze)}
+		windows := []config.MaintenanceWindow{{Schedule: "0 11 * * *", Duration: 2 * time.Hour}}
+		if status := state.status(now, windows); status.reason != muteMaintenance {
+			t.Errorf("Expected maintenance, got %+v", status)
+		}
+
+		state.resume()
+		if status := state.status(now, windows); status.reason != muteMaintenance {
+			t.Errorf("Expected maintenance to outlast resuming, got %+v", status)
+		}
+	})
+}
+
+func TestFormatAlertsTitle(t *testing.T) {
+	now := time.Date(2024, 6, 8, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name     string
+		status   muteStatus
+		expected string
+	}{
+		{name: "On", status: muteStatus{}, expected: "🔔 Alerts: On"},
+		{name: "Snoozed", status: muteStatus{reason: muteSnoozed, until: now.Add(longSnooze)}, expected: "🔕 Alerts: Snoozed until 13:00"},
+		{name: "Maintenance ending tomorrow", status: muteStatus{reason: muteMaintenance, until: now.Add(14 * time.Hour)}, expected: "🔕 Alerts: Maintenance until Sun 02:00"},
+		{name: "Until healthy", status: muteStatus{reason: muteUntilHealthy}, expected: "🔕 Alerts: Snoozed until healthy"},
+		{name: "Acknowledged", status: muteStatus{reason: muteAcknowledged, pods: 3}, expected: "🔕 Alerts: Acknowledged (3 failing pods)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := formatAlertsTitle(tt.status, now); result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,305 @@
+package tray
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mattlqx/k8s-tray/internal/hooks"
+	"github.com/mattlqx/k8s-tray/internal/kubernetes"
+	"github.com/mattlqx/k8s-tray/internal/notify"
+	"github.com/mattlqx/k8s-tray/pkg/models"
+)
+
+// notificationAppName identifies the app in desktop notifications
+const notificationAppName = "K8s Tray"
+
+// maxNotifiedPods limits the pod names listed in one notification
+const maxNotifiedPods = 3
+
+// podAlertCooldown is how long a pod is not notified about again after entering
+// an issue, so that pods flapping between states do not repeat notifications
+const podAlertCooldown = 15 * time.Minute
+
+// healthSnapshot is what the last notification decisions were based on
+type healthSnapshot struct {
+	known      bool // False until the first refresh of a context or namespace
+	connection models.ConnectionState
+	health     models.HealthStatus
+	summary    string                     // Pod problems, e.g. "2 failed, 1 crash looping"
+	podIssues  map[string]models.PodIssue // Pods with a container issue, keyed by namespace/name
+	alerted    map[string]time.Time       // When pods were last notified about entering an issue
+	updated    time.Time
+}
+
+// newHealthSnapshot captures the health of a successfully refreshed status
+func newHealthSnapshot(status *models.ClusterStatus) healthSnapshot {
+	snapshot := healthSnapshot{
+		known:      true,
+		connection: models.ConnectionOK,
+		health:     status.HealthStatus,
+		podIssues:  make(map[string]models.PodIssue),
+		updated:    status.LastUpdated,
+	}
+	if status.PodStatus == nil {
+		return snapshot
+	}
+
+	snapshot.summary = formatPodProblems(status.PodStatus)
+	for _, pod := range status.PodStatus.Details {
+		if pod.Issue != models.PodIssueNone {
+			snapshot.podIssues[pod.Namespace+"/"+pod.Name] = pod.Issue
+		}
+	}
+	return snapshot
+}
+
+// withAlerted returns the snapshot with the pods notified about within
+// podAlertCooldown, including those that entered an issue since prev
+func (s healthSnapshot) withAlerted(prev healthSnapshot) healthSnapshot {
+	s.alerted = make(map[string]time.Time)
+	for pod, at := range prev.alerted {
+		if s.updated.Sub(at) < podAlertCooldown {
+			s.alerted[pod] = at
+		}
+	}
+	if prev.known {
+		for pod := range enteredIssues(prev, s) {
+			s.alerted[pod] = s.updated
+		}
+	}
+	return s
+}
+
+// withError returns the snapshot after a failed refresh. The last known
+// health is kept, so that reconnecting is compared with the state before the
+// outage rather than with nothing.
+func (s healthSnapshot) withError(err error) healthSnapshot {
+	s.known = true
+	s.connection = kubernetes.ClassifyError(err)
+	return s
+}
+
+// healthNotifications returns notifications for the changes between two
+// snapshots of a context: connection lost or regained, health transitions and
+// pods entering a container issue
+func healthNotifications(contextName string, prev, curr healthSnapshot) []notify.Notification {
+	var notifications []notify.Notification
+
+	// Connection problems are reported once per kind of failure
+	if curr.connection != models.ConnectionOK {
+		if !prev.known || prev.connection != curr.connection {
+			notifications = append(notifications, notify.Notification{
+				Title:      fmt.Sprintf("%s: %s", contextName, curr.connection),
+				Body:       curr.connection.Hint(),
+				Severity:   models.HealthCritical,
+				Connection: true,
+			})
+		}
+		return notifications
+	}
+
+	// Nothing to compare the first status of a context or namespace with
+	if !prev.known {
+		return nil
+	}
+
+	if prev.connection != models.ConnectionOK {
+		notifications = append(notifications, notify.Notification{
+			Title:      fmt.Sprintf("%s: Reconnected", contextName),
+			Body:       fmt.Sprintf("Cluster is %s", curr.health),
+			Severity:   models.HealthHealthy,
+			Connection: true,
+		})
+	}
+
+	if notification, changed := healthTransition(contextName, prev, curr); changed {
+		notifications = append(notifications, notification)
+	}
+
+	return append(notifications, podIssueNotifications(contextName, prev, curr)...)
+}
+
+// healthTransition returns a notification when the health changed between
+// known values, e.g. "prod: Healthy → Critical" or "prod: Recovered"
+func healthTransition(contextName string, prev, curr healthSnapshot) (notify.Notification, bool) {
+	if prev.health == curr.health || prev.health == models.HealthUnknown || curr.health == models.HealthUnknown {
+		return notify.Notification{}, false
+	}
+
+	if curr.health == models.HealthHealthy {
+		return notify.Notification{
+			Title:    fmt.Sprintf("%s: Recovered", contextName),
+			Body:     fmt.Sprintf("Healthy again after being %s", prev.health),
+			Severity: models.HealthHealthy,
+		}, true
+	}
+
+	return notify.Notification{
+		Title:    fmt.Sprintf("%s: %s → %s", contextName, prev.health, curr.health),
+		Body:     curr.summary,
+		Severity: curr.health,
+	}, true
+}
+
+// enteredIssues returns the pods that entered an issue since the previous
+// snapshot, leaving out those notified about within podAlertCooldown
+func enteredIssues(prev, curr healthSnapshot) map[string]models.PodIssue {
+	entered := make(map[string]models.PodIssue)
+	for pod, issue := range curr.podIssues {
+		if prev.podIssues[pod] == issue {
+			continue
+		}
+		if at, ok := prev.alerted[pod]; ok && curr.updated.Sub(at) < podAlertCooldown {
+			continue
+		}
+		entered[pod] = issue
+	}
+	return entered
+}
+
+// podIssueNotifications returns one notification per issue for pods that
+// entered it since the previous snapshot
+func podIssueNotifications(contextName string, prev, curr healthSnapshot) []notify.Notification {
+	entered := make(map[models.PodIssue][]string)
+	for pod, issue := range enteredIssues(prev, curr) {
+		entered[issue] = append(entered[issue], pod)
+	}
+
+	var notifications []notify.Notification
+	for _, issue := range []models.PodIssue{
+		models.PodIssueCrashLoop, models.PodIssueOOMKilled, models.PodIssueImagePull, models.PodIssueConfigError,
+	} {
+		pods := entered[issue]
+		if len(pods) == 0 {
+			continue
+		}
+		sort.Strings(pods)
+
+		notifications = append(notifications, notify.Notification{
+			Title:    fmt.Sprintf("%s: %s", contextName, issue),
+			Body:     formatEnteredIssue(pods, issue),
+			Severity: issue.Severity(),
+		})
+	}
+	return notifications
+}
+
+// formatEnteredIssue describes pods entering an issue, e.g.
+// "default/api entered CrashLoopBackOff" or "5 pods entered OOMKilled: a, b, c, …"
+func formatEnteredIssue(pods []string, issue models.PodIssue) string {
+	if len(pods) == 1 {
+		return fmt.Sprintf("%s entered %s", pods[0], issue)
+	}
+
+	names := pods
+	if len(names) > maxNotifiedPods {
+		names = append(names[:maxNotifiedPods:maxNotifiedPods], "…")
+	}
+	return fmt.Sprintf("%d pods entered %s: %s", len(pods), issue, strings.Join(names, ", "))
+}
+
+// formatPodProblems summarizes pod problems, e.g. "2 failed, 1 crash looping"
+func formatPodProblems(status *models.PodStatus) string {
+	var parts []string
+	for _, count := range []struct {
+		n     int
+		label string
+	}{
+		{status.CrashLoop, "crash looping"},
+		{status.OOMKilled, "OOMKilled"},
+		{status.Failed - status.FailedJobPods, "failed"},
+		{status.ImagePullError, "failing to pull images"},
+		{status.ConfigError, "misconfigured"},
+		{status.RunningNotReady, "not ready"},
+		{status.Pending, "pending"},
+	} {
+		if count.n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", count.n, count.label))
+		}
+	}
+
+	if len(parts) == 0 {
+		return "No pod problems"
+	}
+	return "Pods: " + strings.Join(parts, ", ")
+}
+
+// notifyHealthChange records the result of a refresh and notifies about what
+// changed since the previous one, unless alerts are muted; changes are passed
+// on for webhooks
+func (m *Manager) notifyHealthChange(status *models.ClusterStatus, changes []models.Change, err error) {
+	prev := m.notified
+	if err != nil {
+		m.notified = prev.withError(err)
+	} else {
+		m.notified = newHealthSnapshot(status).withAlerted(prev)
+	}
+
+	contextName := ""
+	if status != nil {
+		contextName = status.ClusterName
+	} else if name, err := m.k8sClient.GetCurrentContext(); err == nil {
+		contextName = name
+	}
+
+	m.observeMute(contextName, status)
+	if m.notifier == nil {
+		return
+	}
+
+	notifications := healthNotifications(contextName, prev, m.notified)
+	if len(notifications) > 0 && m.currentMute().reason != muteNone {
+		log.Printf("Alerts are muted, skipping %d notifications about %s", len(notifications), contextName)
+		return
+	}
+
+	now := time.Now()
+	for _, notification := range notifications {
+		notification.Context = contextName
+		notification.Time = now
+		notification.Status = status
+		notification.Changes = changes
+		m.notifier.Notify(notification)
+	}
+}
+
+// runHooks starts the hook commands for the health and pod state changes
+// between two refreshes. After the connection was lost, the health changes
+// from unknown instead. m.statusMu must be held.
+func (m *Manager) runHooks(prev, curr *models.ClusterStatus, changes []models.Change) {
+	if m.hookRunner == nil {
+		return
+	}
+	if m.hookConnection != models.ConnectionOK && prev != nil {
+		outage := *prev
+		outage.HealthStatus = models.HealthUnknown
+		prev = &outage
+	}
+	m.hookConnection = models.ConnectionOK
+	if m.currentMute().reason != muteNone {
+		return
+	}
+
+	for _, event := range hooks.Events(curr.ClusterName, m.config.Namespace, prev, curr, changes) {
+		m.hookRunner.Fire(m.mainCtx, event)
+	}
+}
+
+// runConnectionHooks starts the health hook commands when a refresh failed
+// after the last one succeeded, or failed for another reason; m.statusMu must be held
+func (m *Manager) runConnectionHooks(err error) {
+	connection := kubernetes.ClassifyError(err)
+	if m.hookRunner == nil || m.currentStatus == nil || connection == m.hookConnection {
+		return
+	}
+	m.hookConnection = connection
+	if m.currentMute().reason != muteNone {
+		return
+	}
+
+	event := hooks.ConnectionLost(m.currentStatus.ClusterName, m.config.Namespace, m.currentStatus, connection, time.Now())
+	m.hookRunner.Fire(m.mainCtx, event)
+}
@@ -0,0 +1,257 @@
+package tray
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mattlqx/k8s-tray/internal/config"
+	"github.com/mattlqx/k8s-tray/internal/hooks"
+	"github.com/mattlqx/k8s-tray/internal/notify"
+	"github.com/mattlqx/k8s-tray/pkg/models"
+)
+
+// fakeNotifyBackend records notifications instead of showing them
+type fakeNotifyBackend struct {
+	mu   sync.Mutex
+	sent []notify.Notification
+}
+
+func (f *fakeNotifyBackend) Send(_ context.Context, notification notify.Notification) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = append(f.sent, notification)
+	return nil
+}
+
+func (f *fakeNotifyBackend) titles() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	titles := make([]string, len(f.sent))
+	for i, notification := range f.sent {
+		titles[i] = notification.Title
+	}
+	return titles
+}
+
+// testStatus creates a status with the given health and pods
+func testStatus(health models.HealthStatus, pods ...models.PodDetail) *models.ClusterStatus {
+	return &models.ClusterStatus{
+		ClusterName:  "prod",
+		HealthStatus: health,
+		PodStatus:    &models.PodStatus{Total: len(pods), Details: pods},
+	}
+}
+
+func TestHealthNotifications(t *testing.T) {
+	refused := errors.New("dial tcp 10.0.0.1:6443: connect: connection refused")
+	healthy := newHealthSnapshot(testStatus(models.HealthHealthy))
+	critical := newHealthSnapshot(testStatus(models.HealthCritical,
+		models.PodDetail{Name: "api", Namespace: "default", Phase: "Running", Issue: models.PodIssueCrashLoop}))
+	warning := newHealthSnapshot(testStatus(models.HealthWarning))
+
+	tests := []struct {
+		name     string
+		prev     healthSnapshot
+		curr     healthSnapshot
+		expected []string
+	}{
+		{name: "First status", prev: healthSnapshot{}, curr: critical, expected: nil},
+		{name: "Unreachable at startup", prev: healthSnapshot{}, curr: healthSnapshot{}.withError(refused), expected: []string{"prod: Unreachable"}},
+		{name: "No change", prev: healthy, curr: healthy, expected: nil},
+		{name: "Degraded", prev: healthy, curr: critical, expected: []string{"prod: Healthy → Critical", "prod: CrashLoopBackOff"}},
+		{name: "Improved", prev: critical, curr: warning, expected: []string{"prod: Critical → Warning"}},
+		{name: "Recovered", prev: critical, curr: healthy, expected: []string{"prod: Recovered"}},
+		{name: "Lost connection", prev: healthy, curr: healthy.withError(refused), expected: []string{"prod: Unreachable"}},
+		{name: "Still unreachable", prev: healthy.withError(refused), curr: healthy.withError(refused).withError(refused), expected: nil},
+		{name: "Reconnected", prev: healthy.withError(refused), curr: healthy, expected: []string{"prod: Reconnected"}},
+		{name: "Reconnected degraded", prev: healthy.withError(refused), curr: critical, expected: []string{"prod: Reconnected", "prod: Healthy → Critical", "prod: CrashLoopBackOff"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var titles []string
+			for _, notification := range healthNotifications("prod", tt.prev, tt.curr) {
+				titles = append(titles, notification.Title)
+			}
+			if len(titles) != len(tt.expected) {
+				t.Fatalf("Expected %v, got %v", tt.expected, titles)
+			}
+			for i := range titles {
+				if titles[i] != tt.expected[i] {
+					t.Errorf("Expected '%s', got '%s'", tt.expected[i], titles[i])
+				}
+			}
+		})
+	}
+}
+
+func TestHealthNotifications_PodCooldown(t *testing.T) {
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	ready := models.PodDetail{Name: "api", Namespace: "default", Phase: "Running", Ready: true}
+	crashing := models.PodDetail{Name: "api", Namespace: "default", Phase: "Running", Issue: models.PodIssueCrashLoop}
+
+	// The pod flaps between running and crash looping
+	steps := []struct {
+		after    time.Duration
+		pod      models.PodDetail
+		expected int // Pod issue notifications
+	}{
+		{after: 0, pod: ready, expected: 0},
+		{after: time.Minute, pod: crashing, expected: 1},
+		{after: 2 * time.Minute, pod: ready, expected: 0},
+		{after: 3 * time.Minute, pod: crashing, expected: 0},
+		{after: 10 * time.Minute, pod: ready, expected: 0},
+		{after: 20 * time.Minute, pod: crashing, expected: 1},
+	}
+
+	var prev healthSnapshot
+	for _, step := range steps {
+		status := testStatus(models.HealthHealthy, step.pod)
+		status.LastUpdated = start.Add(step.after)
+		curr := newHealthSnapshot(status).withAlerted(prev)
+
+		if notifications := podIssueNotifications("prod", prev, curr); len(notifications) != step.expected {
+			t.Errorf("After %s: expected %d notifications, got %+v", step.after, step.expected, notifications)
+		}
+		prev = curr
+	}
+}
+
+func TestFormatEnteredIssue(t *testing.T) {
+	tests := []struct {
+		name     string
+		pods     []string
+		expected string
+	}{
+		{name: "One pod", pods: []string{"default/api"}, expected: "default/api entered CrashLoopBackOff"},
+		{name: "Several pods", pods: []string{"default/a", "default/b"}, expected: "2 pods entered CrashLoopBackOff: default/a, default/b"},
+		{
+			name:     "Many pods",
+			pods:     []string{"default/a", "default/b", "default/c", "default/d", "default/e"},
+			expected: "5 pods entered CrashLoopBackOff: default/a, default/b, default/c, …",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := formatEnteredIssue(tt.pods, models.PodIssueCrashLoop); result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestFormatPodProblems(t *testing.T) {
+	status := &models.PodStatus{CrashLoop: 1, Failed: 3, FailedJobPods: 1, Pending: 2}
+	expected := "Pods: 1 crash looping, 2 failed, 2 pending"
+	if result := formatPodProblems(status); result != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, result)
+	}
+}
+
+func TestNotifyHealthChange(t *testing.T) {
+	backend := &fakeNotifyBackend{}
+	m := &Manager{
+		config:    &config.Config{},
+		k8sClient: &fakeSource{contextName: "prod"},
+		notifier:  notify.New(backend),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go m.notifier.Run(ctx)
+
+	m.notifyHealthChange(testStatus(models.HealthHealthy), nil, nil)
+	m.notifyHealthChange(nil, nil, errors.New("dial tcp 10.0.0.1:6443: connect: connection refused"))
+	m.notifyHealthChange(testStatus(models.HealthHealthy), nil, nil)
+
+	expected := []string{"prod: Unreachable", "prod: Reconnected"}
+	deadline := time.Now().Add(time.Second)
+	for len(backend.titles()) < len(expected) && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	titles := backend.titles()
+	if len(titles) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, titles)
+	}
+	for i := range titles {
+		if titles[i] != expected[i] {
+			t.Errorf("Expected '%s', got '%s'", expected[i], titles[i])
+		}
+	}
+}
+
+func TestNotifyHealthChange_Muted(t *testing.T) {
+	backend := &fakeNotifyBackend{}
+	m := &Manager{
+		config:    &config.Config{},
+		k8sClient: &fakeSource{contextName: "prod"},
+		notifier:  notify.New(backend),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go m.notifier.Run(ctx)
+
+	m.notifyHealthChange(testStatus(models.HealthHealthy), nil, nil)
+	m.snoozeUntilHealthy()
+	m.notifyHealthChange(testStatus(models.HealthCritical), nil, nil)
+	m.notifyHealthChange(testStatus(models.HealthHealthy), nil, nil)
+	m.notifyHealthChange(testStatus(models.HealthWarning), nil, nil)
+
+	// The outage during the snooze is not notified, the recovery ending it is
+	expected := []string{"prod: Recovered", "prod: Healthy → Warning"}
+	deadline := time.Now().Add(time.Second)
+	for len(backend.titles()) < len(expected) && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	titles := backend.titles()
+	if len(titles) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, titles)
+	}
+	for i := range titles {
+		if titles[i] != expected[i] {
+			t.Errorf("Expected '%s', got '%s'", expected[i], titles[i])
+		}
+	}
+}
+
+func TestRunHooks_Connection(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Hook commands are run through sh in this test")
+	}
+	dir := t.TempDir()
+	t.Setenv("OUT", dir)
+
+	m := &Manager{
+		config:     &config.Config{},
+		mainCtx:    context.Background(),
+		hookRunner: hooks.New([]config.Hook{{Command: `echo "$K8S_TRAY_OLD_HEALTH $K8S_TRAY_NEW_HEALTH $K8S_TRAY_CONNECTION" >> "$OUT/runs"`}}, 1),
+	}
+	m.currentStatus = testStatus(models.HealthHealthy)
+
+	// Failing the same way again does not run hooks twice
+	refused := errors.New("dial tcp 10.0.0.1:6443: connect: connection refused")
+	m.runConnectionHooks(refused)
+	m.hookRunner.Wait()
+	m.runConnectionHooks(refused)
+	m.hookRunner.Wait()
+	m.runHooks(m.currentStatus, testStatus(models.HealthHealthy), nil)
+	m.hookRunner.Wait()
+
+	runs, err := os.ReadFile(filepath.Join(dir, "runs"))
+	if err != nil {
+		t.Fatalf("Hooks did not run: %v", err)
+	}
+	if expected := "Healthy Unknown Unreachable\nUnknown Healthy \n"; string(runs) != expected {
+		t.Errorf("Expected %q, got %q", expected, runs)
+	}
+}
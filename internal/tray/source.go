@@ -0,0 +1,33 @@
+package tray
+
+import (
+	"context"
+	"time"
+
+	"github.com/mattlqx/k8s-tray/internal/config"
+	"github.com/mattlqx/k8s-tray/internal/kubernetes"
+	"github.com/mattlqx/k8s-tray/pkg/models"
+)
+
+// ClusterSource provides the cluster data shown in the tray
+type ClusterSource interface {
+	GetClusterStatus(ctx context.Context) (*models.ClusterStatus, error)
+	TestConnection(ctx context.Context) error
+	Watch(ctx context.Context, debounce time.Duration) (<-chan *models.ClusterStatus, error)
+	GetAllNamespaces(ctx context.Context) ([]string, error)
+	GetContextSources() ([]models.KubeContext, error)
+	GetCurrentContext() (string, error)
+	GetPodLogs(ctx context.Context, namespace, name string, tailLines int64) ([]models.ContainerLog, error)
+}
+
+// The Kubernetes client is the production ClusterSource
+var _ ClusterSource = (*kubernetes.Client)(nil)
+
+// SourceFactory creates a ClusterSource for the named context, or the
+// kubeconfig's current-context when contextName is empty
+type SourceFactory func(cfg *config.Config, contextName string) (ClusterSource, error)
+
+// newKubeconfigSource creates a Kubernetes client from the kubeconfig
+func newKubeconfigSource(cfg *config.Config, contextName string) (ClusterSource, error) {
+	return kubernetes.NewClientForContext(cfg, contextName)
+}
@@ -0,0 +1,123 @@
+package tray
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mattlqx/k8s-tray/internal/config"
+	"github.com/mattlqx/k8s-tray/pkg/models"
+)
+
+// fakeSource is a ClusterSource returning canned results
+type fakeSource struct {
+	contextName string
+	status      *models.ClusterStatus
+	statusErr   error
+	connectErr  error
+	hang        bool // TestConnection blocks until ctx is done
+}
+
+func (f *fakeSource) GetClusterStatus(context.Context) (*models.ClusterStatus, error) {
+	return f.status, f.statusErr
+}
+
+func (f *fakeSource) TestConnection(ctx context.Context) error {
+	if f.hang {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return f.connectErr
+}
+
+func (f *fakeSource) Watch(context.Context, time.Duration) (<-chan *models.ClusterStatus, error) {
+	return nil, errors.New("watch not supported")
+}
+
+func (f *fakeSource) GetAllNamespaces(context.Context) ([]string, error) {
+	return nil, nil
+}
+
+func (f *fakeSource) GetContextSources() ([]models.KubeContext, error) {
+	return nil, nil
+}
+
+func (f *fakeSource) GetCurrentContext() (string, error) {
+	return f.contextName, nil
+}
+
+func (f *fakeSource) GetPodLogs(context.Context, string, string, int64) ([]models.ContainerLog, error) {
+	return nil, nil
+}
+
+func TestConnectContext(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		source    *fakeSource
+		createErr error
+		expectErr bool
+	}{
+		{
+			name:   "Reachable",
+			ctx:    context.Background(),
+			source: &fakeSource{contextName: "prod"},
+		},
+		{
+			name:      "Client cannot be created",
+			ctx:       context.Background(),
+			createErr: errors.New("context not found"),
+			expectErr: true,
+		},
+		{
+			name:      "Connection refused",
+			ctx:       context.Background(),
+			source:    &fakeSource{contextName: "prod", connectErr: errors.New("connection refused")},
+			expectErr: true,
+		},
+		{
+			name:      "Connection check gives up",
+			ctx:       cancelled,
+			source:    &fakeSource{contextName: "prod", hang: true},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Manager{
+				config: &config.Config{Context: "dev"},
+				newSource: func(_ *config.Config, contextName string) (ClusterSource, error) {
+					if tt.createErr != nil {
+						return nil, tt.createErr
+					}
+					if contextName != "prod" {
+						t.Errorf("Expected client for 'prod', got '%s'", contextName)
+					}
+					return tt.source, nil
+				},
+			}
+
+			source, err := m.connectContext(tt.ctx, "prod")
+			if tt.expectErr {
+				if err == nil {
+					t.Error("Expected an error")
+				}
+				if source != nil {
+					t.Error("Expected no source on failure")
+				}
+			} else if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+
+			// Nothing is committed while connecting
+			if m.config.Context != "dev" {
+				t.Errorf("Expected context to remain 'dev', got '%s'", m.config.Context)
+			}
+		})
+	}
+}
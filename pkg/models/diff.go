@@ -0,0 +1,267 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ChangeKind classifies a change between two cluster status snapshots
+type ChangeKind string
+
+// Kinds of changes
+const (
+	ChangeHealth            ChangeKind = "health"             // Overall health transition
+	ChangeResourceThreshold ChangeKind = "resource_threshold" // CPU or memory usage crossed a threshold
+	ChangePodAdded          ChangeKind = "pod_added"
+	ChangePodRemoved        ChangeKind = "pod_removed"
+	ChangePodState          ChangeKind = "pod_state"     // Pod moved to another category, e.g. Ready → CrashLoopBackOff
+	ChangePodRestarted      ChangeKind = "pod_restarted" // Restart count increased
+)
+
+// Change is one difference between consecutive cluster status snapshots
+type Change struct {
+	Time     time.Time    `json:"time"`
+	Kind     ChangeKind   `json:"kind"`
+	Object   string       `json:"object"` // "cluster", "CPU", "Memory" or the pod's namespace/name
+	From     string       `json:"from,omitempty"`
+	To       string       `json:"to,omitempty"`
+	Severity HealthStatus `json:"severity"` // Healthy for improvements and informational changes
+	Message  string       `json:"message"`
+}
+
+// ResourceThresholds are the usage percentages whose crossing is reported
+var ResourceThresholds = []float64{80, 90}
+
+// Label returns the display name of the pod category
+func (c PodCategory) Label() string {
+	switch c {
+	case PodCategoryReady:
+		return "Ready"
+	case PodCategoryNotReady:
+		return "Not Ready"
+	case PodCategoryPending:
+		return "Pending"
+	case PodCategoryCompleted:
+		return "Completed"
+	case PodCategoryFailed:
+		return "Failed"
+	case PodCategoryCrashLoop:
+		return PodIssueCrashLoop.String()
+	case PodCategoryImagePull:
+		return PodIssueImagePull.String()
+	case PodCategoryConfigError:
+		return PodIssueConfigError.String()
+	case PodCategoryOOMKilled:
+		return PodIssueOOMKilled.String()
+	default:
+		return "Unknown"
+	}
+}
+
+// Severity returns the health status a pod in this category contributes
+func (c PodCategory) Severity() HealthStatus {
+	switch c {
+	case PodCategoryFailed, PodCategoryCrashLoop, PodCategoryOOMKilled:
+		return HealthCritical
+	case PodCategoryNotReady, PodCategoryPending, PodCategoryImagePull, PodCategoryConfigError:
+		return HealthWarning
+	case PodCategoryUnknown:
+		return HealthUnknown
+	default:
+		return HealthHealthy
+	}
+}
+
+// Diff returns the changes from prev to curr, stamped with curr's update
+// time: the health transition first, then resource threshold crossings, then
+// pod changes ordered by pod. There are no changes without a previous snapshot.
+//
+// Details only hold the most relevant pods of large categories, so a pod
+// missing from one snapshot may merely have been left out of it. Pods are
+// reported as added or removed only when no pods were left out of the
+// snapshot they are missing from, nor of their category in either snapshot.
+func Diff(prev, curr *ClusterStatus) []Change {
+	if prev == nil || curr == nil {
+		return nil
+	}
+
+	var changes []Change
+	if prev.HealthStatus != curr.HealthStatus {
+		changes = append(changes, Change{
+			Kind:     ChangeHealth,
+			Object:   "cluster",
+			From:     prev.HealthStatus.String(),
+			To:       curr.HealthStatus.String(),
+			Severity: curr.HealthStatus,
+			Message:  fmt.Sprintf("Cluster health changed from %s to %s", prev.HealthStatus, curr.HealthStatus),
+		})
+	}
+
+	if prev.Resources != nil && curr.Resources != nil {
+		changes = appendThresholdChange(changes, "CPU", prev.Resources.CPU, prev.Resources.MetricsAvailable,
+			curr.Resources.CPU, curr.Resources.MetricsAvailable)
+		changes = appendThresholdChange(changes, "Memory", prev.Resources.Memory, prev.Resources.MetricsAvailable,
+			curr.Resources.Memory, curr.Resources.MetricsAvailable)
+	}
+
+	if prev.PodStatus != nil && curr.PodStatus != nil {
+		changes = append(changes, diffPods(prev.PodStatus, curr.PodStatus)...)
+	}
+
+	for i := range changes {
+		changes[i].Time = curr.LastUpdated
+	}
+	return changes
+}
+
+// usagePercentage returns the percentage shown for a resource: actual usage
+// when metrics are available, otherwise the requested amount
+func usagePercentage(stat *ResourceStat, metricsAvailable bool) float64 {
+	if metricsAvailable {
+		return stat.Percentage
+	}
+	return stat.RequestedPercentage
+}
+
+// appendThresholdChange appends a change when the resource's usage crossed
+// one of the ResourceThresholds, reporting only the highest one crossed
+func appendThresholdChange(changes []Change, resource string, prev *ResourceStat, prevMetrics bool, curr *ResourceStat, currMetrics bool) []Change {
+	if prev == nil || curr == nil || prevMetrics != currMetrics {
+		// Usage and requests are not comparable
+		return changes
+	}
+
+	before, after := usagePercentage(prev, prevMetrics), usagePercentage(curr, currMetrics)
+	crossed, rising := 0.0, after > before
+	for _, threshold := range ResourceThresholds {
+		if (before < threshold && after >= threshold) || (before >= threshold && after < threshold) {
+			if crossed == 0 || (rising && threshold > crossed) || (!rising && threshold < crossed) {
+				crossed = threshold
+			}
+		}
+	}
+	if crossed == 0 {
+		return changes
+	}
+
+	change := Change{
+		Kind:   ChangeResourceThreshold,
+		Object: resource,
+		From:   fmt.Sprintf("%.0f%%", before),
+		To:     fmt.Sprintf("%.0f%%", after),
+	}
+	if rising {
+		change.Severity = HealthWarning
+		if crossed >= ResourceThresholds[len(ResourceThresholds)-1] {
+			change.Severity = HealthCritical
+		}
+		change.Message = fmt.Sprintf("%s usage rose above %.0f%% (%.0f%%)", resource, crossed, after)
+	} else {
+		change.Severity = HealthHealthy
+		change.Message = fmt.Sprintf("%s usage fell below %.0f%% (%.0f%%)", resource, crossed, after)
+	}
+	return append(changes, change)
+}
+
+// diffPods compares the pod details of two snapshots, ordered by pod
+func diffPods(prev, curr *PodStatus) []Change {
+	prevPods := make(map[string]PodDetail, len(prev.Details))
+	for _, pod := range prev.Details {
+		prevPods[pod.Namespace+"/"+pod.Name] = pod
+	}
+	currPods := make(map[string]PodDetail, len(curr.Details))
+	for _, pod := range curr.Details {
+		currPods[pod.Namespace+"/"+pod.Name] = pod
+	}
+
+	var changes []Change
+	for key, pod := range currPods {
+		before, existed := prevPods[key]
+		category := pod.Category()
+		if !existed {
+			switch {
+			case !prev.omittedAny() && curr.Omitted[category] == 0:
+				changes = append(changes, Change{
+					Kind:     ChangePodAdded,
+					Object:   key,
+					To:       category.Label(),
+					Severity: HealthHealthy,
+					Message:  fmt.Sprintf("Pod %s was created (%s)", key, category.Label()),
+				})
+			case prev.Omitted[category] == 0 && category.Severity() != HealthHealthy:
+				// New, or left out of another category before: either way it entered this one
+				changes = append(changes, Change{
+					Kind:     ChangePodState,
+					Object:   key,
+					To:       category.Label(),
+					Severity: category.Severity(),
+					Message:  fmt.Sprintf("Pod %s entered %s", key, category.Label()),
+				})
+			}
+			continue
+		}
+
+		if previous := before.Category(); previous != category {
+			changes = append(changes, Change{
+				Kind:     ChangePodState,
+				Object:   key,
+				From:     previous.Label(),
+				To:       category.Label(),
+				Severity: category.Severity(),
+				Message:  podStateMessage(key, previous, category),
+			})
+		}
+
+		if pod.Restarts > before.Restarts {
+			changes = append(changes, Change{
+				Kind:     ChangePodRestarted,
+				Object:   key,
+				From:     fmt.Sprint(before.Restarts),
+				To:       fmt.Sprint(pod.Restarts),
+				Severity: HealthWarning,
+				Message:  fmt.Sprintf("Pod %s restarted %d times (%d total)", key, pod.Restarts-before.Restarts, pod.Restarts),
+			})
+		}
+	}
+
+	for key, pod := range prevPods {
+		category := pod.Category()
+		if _, exists := currPods[key]; !exists && !curr.omittedAny() && prev.Omitted[category] == 0 {
+			changes = append(changes, Change{
+				Kind:     ChangePodRemoved,
+				Object:   key,
+				From:     category.Label(),
+				Severity: HealthHealthy,
+				Message:  fmt.Sprintf("Pod %s was deleted", key),
+			})
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Object != changes[j].Object {
+			return changes[i].Object < changes[j].Object
+		}
+		return changes[i].Kind < changes[j].Kind
+	})
+	return changes
+}
+
+// omittedAny reports whether any pods were left out of the details
+func (s *PodStatus) omittedAny() bool {
+	for _, count := range s.Omitted {
+		if count > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// podStateMessage describes a pod moving between categories, e.g.
+// "Pod default/api entered CrashLoopBackOff" or "Pod default/api recovered"
+func podStateMessage(pod string, from, to PodCategory) string {
+	if to.Severity() == HealthHealthy && from.Severity() != HealthHealthy {
+		return fmt.Sprintf("Pod %s recovered (%s → %s)", pod, from.Label(), to.Label())
+	}
+	return fmt.Sprintf("Pod %s entered %s (was %s)", pod, to.Label(), from.Label())
+}
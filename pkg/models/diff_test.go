@@ -0,0 +1,206 @@
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// diffSnapshot creates a snapshot with the given health and pods
+func diffSnapshot(health HealthStatus, pods ...PodDetail) *ClusterStatus {
+	return &ClusterStatus{
+		HealthStatus: health,
+		PodStatus:    &PodStatus{Total: len(pods), Details: pods},
+		LastUpdated:  time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestDiff(t *testing.T) {
+	ready := PodDetail{Name: "api", Namespace: "default", Phase: "Running", Ready: true}
+	crashing := PodDetail{Name: "api", Namespace: "default", Phase: "Running", Issue: PodIssueCrashLoop, Restarts: 3}
+	worker := PodDetail{Name: "worker", Namespace: "default", Phase: "Running", Ready: true}
+
+	tests := []struct {
+		name     string
+		prev     *ClusterStatus
+		curr     *ClusterStatus
+		expected []string // Kind and object of each change
+	}{
+		{name: "First snapshot", prev: nil, curr: diffSnapshot(HealthHealthy, ready), expected: nil},
+		{name: "No change", prev: diffSnapshot(HealthHealthy, ready), curr: diffSnapshot(HealthHealthy, ready), expected: nil},
+		{
+			name:     "Pod entered CrashLoopBackOff",
+			prev:     diffSnapshot(HealthHealthy, ready, worker),
+			curr:     diffSnapshot(HealthCritical, crashing, worker),
+			expected: []string{"health cluster", "pod_restarted default/api", "pod_state default/api"},
+		},
+		{
+			name:     "Pods added and removed",
+			prev:     diffSnapshot(HealthHealthy, ready),
+			curr:     diffSnapshot(HealthHealthy, worker),
+			expected: []string{"pod_removed default/api", "pod_added default/worker"},
+		},
+		{
+			name: "Pod left out of a capped category",
+			prev: diffSnapshot(HealthHealthy, ready, worker),
+			curr: func() *ClusterStatus {
+				status := diffSnapshot(HealthHealthy, worker)
+				status.PodStatus.Omitted = map[PodCategory]int{PodCategoryReady: 1}
+				return status
+			}(),
+			expected: nil,
+		},
+		{
+			name: "Pod left out of another category before",
+			prev: func() *ClusterStatus {
+				status := diffSnapshot(HealthHealthy, worker)
+				status.PodStatus.Omitted = map[PodCategory]int{PodCategoryReady: 1}
+				return status
+			}(),
+			curr:     diffSnapshot(HealthCritical, crashing, worker),
+			expected: []string{"health cluster", "pod_state default/api"},
+		},
+		{
+			name: "Healthy pod left out of another category before",
+			prev: func() *ClusterStatus {
+				status := diffSnapshot(HealthHealthy, crashing)
+				status.PodStatus.Omitted = map[PodCategory]int{PodCategoryCrashLoop: 1}
+				return status
+			}(),
+			curr:     diffSnapshot(HealthHealthy, crashing, worker),
+			expected: nil,
+		},
+		{
+			name: "Pod left out of its category before",
+			prev: func() *ClusterStatus {
+				status := diffSnapshot(HealthCritical, worker)
+				status.PodStatus.Omitted = map[PodCategory]int{PodCategoryCrashLoop: 1}
+				return status
+			}(),
+			curr:     diffSnapshot(HealthCritical, crashing, worker),
+			expected: nil,
+		},
+		{
+			name: "Pod left out of another category now",
+			prev: diffSnapshot(HealthCritical, crashing, worker),
+			curr: func() *ClusterStatus {
+				status := diffSnapshot(HealthCritical, worker)
+				status.PodStatus.Omitted = map[PodCategory]int{PodCategoryReady: 1}
+				return status
+			}(),
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result []string
+			for _, change := range Diff(tt.prev, tt.curr) {
+				result = append(result, string(change.Kind)+" "+change.Object)
+				if !change.Time.Equal(tt.curr.LastUpdated) {
+					t.Errorf("Expected change time %v, got %v", tt.curr.LastUpdated, change.Time)
+				}
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestDiff_PodState(t *testing.T) {
+	prev := diffSnapshot(HealthCritical, PodDetail{Name: "api", Namespace: "default", Phase: "Running", Issue: PodIssueCrashLoop})
+	curr := diffSnapshot(HealthHealthy, PodDetail{Name: "api", Namespace: "default", Phase: "Running", Ready: true})
+
+	changes := Diff(prev, curr)
+	if len(changes) != 2 {
+		t.Fatalf("Expected 2 changes, got %+v", changes)
+	}
+
+	change := changes[1]
+	if change.From != "CrashLoopBackOff" || change.To != "Ready" || change.Severity != HealthHealthy {
+		t.Errorf("Unexpected change %+v", change)
+	}
+	expected := "Pod default/api recovered (CrashLoopBackOff → Ready)"
+	if change.Message != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, change.Message)
+	}
+}
+
+func TestDiff_ResourceThresholds(t *testing.T) {
+	withCPU := func(used, requested float64, metrics bool) *ClusterStatus {
+		status := diffSnapshot(HealthHealthy)
+		status.Resources = &ResourceStats{
+			CPU:              &ResourceStat{Percentage: used, RequestedPercentage: requested},
+			MetricsAvailable: metrics,
+		}
+		return status
+	}
+
+	tests := []struct {
+		name     string
+		prev     *ClusterStatus
+		curr     *ClusterStatus
+		expected string
+		severity HealthStatus
+	}{
+		{name: "Below thresholds", prev: withCPU(40, 50, true), curr: withCPU(70, 50, true)},
+		{name: "Rose above one", prev: withCPU(70, 50, true), curr: withCPU(85, 50, true), expected: "CPU usage rose above 80% (85%)", severity: HealthWarning},
+		{name: "Rose above both", prev: withCPU(70, 50, true), curr: withCPU(95, 50, true), expected: "CPU usage rose above 90% (95%)", severity: HealthCritical},
+		{name: "Fell below both", prev: withCPU(95, 50, true), curr: withCPU(50, 50, true), expected: "CPU usage fell below 80% (50%)", severity: HealthHealthy},
+		{name: "Requests without metrics", prev: withCPU(0, 75, false), curr: withCPU(0, 82, false), expected: "CPU usage rose above 80% (82%)", severity: HealthWarning},
+		{name: "Metrics became available", prev: withCPU(0, 95, false), curr: withCPU(40, 95, true)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes := Diff(tt.prev, tt.curr)
+			if tt.expected == "" {
+				if len(changes) != 0 {
+					t.Errorf("Expected no changes, got %+v", changes)
+				}
+				return
+			}
+			if len(changes) != 1 {
+				t.Fatalf("Expected 1 change, got %+v", changes)
+			}
+			if changes[0].Message != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, changes[0].Message)
+			}
+			if changes[0].Severity != tt.severity {
+				t.Errorf("Expected severity %s, got %s", tt.severity, changes[0].Severity)
+			}
+		})
+	}
+}
+
+func TestChange_JSON(t *testing.T) {
+	change := Change{
+		Time:     time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		Kind:     ChangePodState,
+		Object:   "default/api",
+		From:     "Ready",
+		To:       "CrashLoopBackOff",
+		Severity: HealthCritical,
+		Message:  "Pod default/api entered CrashLoopBackOff (was Ready)",
+	}
+
+	data, err := json.Marshal(change)
+	if err != nil {
+		t.Fatalf("Failed to marshal change: %v", err)
+	}
+
+	expected := `{"time":"2024-05-01T12:00:00Z","kind":"pod_state","object":"default/api","from":"Ready","to":"CrashLoopBackOff","severity":3,"message":"Pod default/api entered CrashLoopBackOff (was Ready)"}`
+	if string(data) != expected {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+
+	var decoded Change
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal change: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, change) {
+		t.Errorf("Expected %+v, got %+v", change, decoded)
+	}
+}
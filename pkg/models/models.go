@@ -28,30 +28,186 @@ func (h HealthStatus) String() string {
 	}
 }
 
+// severityRank orders health statuses from least to most severe. Unknown ranks
+// above Healthy so that an undetermined status is never hidden by a healthy one.
+func severityRank(h HealthStatus) int {
+	switch h {
+	case HealthHealthy:
+		return 1
+	case HealthUnknown:
+		return 2
+	case HealthWarning:
+		return 3
+	case HealthCritical:
+		return 4
+	default:
+		return 0
+	}
+}
+
+// WorstHealth returns the most severe of the given health statuses
+func WorstHealth(statuses ...HealthStatus) HealthStatus {
+	if len(statuses) == 0 {
+		return HealthUnknown
+	}
+
+	worst := statuses[0]
+	for _, status := range statuses[1:] {
+		if severityRank(status) > severityRank(worst) {
+			worst = status
+		}
+	}
+	return worst
+}
+
+// ConnectionState classifies whether, and why not, the cluster could be reached
+type ConnectionState int
+
+const (
+	ConnectionOK ConnectionState = iota
+	ConnectionUnreachable
+	ConnectionTimeout
+	ConnectionDNSError
+	ConnectionTLSError
+	ConnectionUnauthorized
+	ConnectionLoginRequired // Exec plugin or OIDC credentials need an interactive login
+	ConnectionForbidden
+	ConnectionServerError
+	ConnectionFailed // Any other error
+)
+
+// String returns the string representation of the connection state
+func (c ConnectionState) String() string {
+	switch c {
+	case ConnectionOK:
+		return "Connected"
+	case ConnectionUnreachable:
+		return "Unreachable"
+	case ConnectionTimeout:
+		return "Timed Out"
+	case ConnectionDNSError:
+		return "DNS Lookup Failed"
+	case ConnectionTLSError:
+		return "TLS Error"
+	case ConnectionUnauthorized:
+		return "Unauthorized"
+	case ConnectionLoginRequired:
+		return "Login Required"
+	case ConnectionForbidden:
+		return "Forbidden"
+	case ConnectionServerError:
+		return "API Server Error"
+	default:
+		return "Connection Failed"
+	}
+}
+
+// Hint returns a remediation hint for the connection state
+func (c ConnectionState) Hint() string {
+	switch c {
+	case ConnectionOK:
+		return ""
+	case ConnectionUnreachable:
+		return "Check your network or VPN connection"
+	case ConnectionTimeout:
+		return "The API server is slow or unreachable; check your network or VPN connection"
+	case ConnectionDNSError:
+		return "The API server's hostname does not resolve; check your VPN or DNS settings"
+	case ConnectionTLSError:
+		return "Certificate verification failed; check the cluster CA and client certificates in your kubeconfig"
+	case ConnectionUnauthorized:
+		return "Credentials were rejected or have expired; log in to the cluster again"
+	case ConnectionLoginRequired:
+		return "Your SSO session has expired; use Log In to sign in again"
+	case ConnectionForbidden:
+		return "Your user lacks permission; ask a cluster admin for access or pick another namespace"
+	case ConnectionServerError:
+		return "The API server reported an error; it may be overloaded or upgrading"
+	default:
+		return "Check the cluster address and your kubeconfig"
+	}
+}
+
 // ResourceStats represents cluster resource usage statistics
 type ResourceStats struct {
-	CPU    *ResourceStat `json:"cpu"`
-	Memory *ResourceStat `json:"memory"`
+	CPU              *ResourceStat `json:"cpu"`
+	Memory           *ResourceStat `json:"memory"`
+	MetricsAvailable bool          `json:"metrics_available"` // Whether Used came from metrics-server
 }
 
-// ResourceStat represents usage statistics for a specific resource
+// ResourceStat represents usage statistics for a specific resource.
+// Amounts are in cores (CPU) or GB (Memory).
 type ResourceStat struct {
-	Used       float64 `json:"used"`       // Used amount in cores (CPU) or GB (Memory)
-	Available  float64 `json:"available"`  // Available amount in cores (CPU) or GB (Memory)
-	Percentage float64 `json:"percentage"` // Usage percentage
+	Used                float64 `json:"used"`                 // Actual usage reported by metrics-server
+	Requested           float64 `json:"requested"`            // Sum of pod requests
+	Limits              float64 `json:"limits"`               // Sum of pod limits
+	Available           float64 `json:"available"`            // Allocatable capacity of all nodes
+	Percentage          float64 `json:"percentage"`           // Actual usage percentage
+	RequestedPercentage float64 `json:"requested_percentage"` // Requested percentage
+	LimitsPercentage    float64 `json:"limits_percentage"`    // Limits percentage
 }
 
 // ClusterStatus represents the overall cluster status
 type ClusterStatus struct {
-	ClusterName   string         `json:"cluster_name"`
-	ServerVersion string         `json:"server_version"`
-	PodStatus     *PodStatus     `json:"pod_status"`
-	Resources     *ResourceStats `json:"resources"`
-	LastUpdated   time.Time      `json:"last_updated"`
-	HealthStatus  HealthStatus   `json:"health_status"`
+	ClusterName   string          `json:"cluster_name"`
+	ServerVersion string          `json:"server_version"`
+	PodStatus     *PodStatus      `json:"pod_status"`
+	Resources     *ResourceStats  `json:"resources"`
+	NodeStatus    *NodeStatus     `json:"node_status,omitempty"`
+	Workloads     *WorkloadStatus `json:"workloads,omitempty"`
+	Jobs          *JobStatus      `json:"jobs,omitempty"`
+	Events        []Event         `json:"events,omitempty"` // Deduplicated Warning events, newest first
+	Permissions   *Permissions    `json:"permissions,omitempty"`
+	LastUpdated   time.Time       `json:"last_updated"`
+	HealthStatus  HealthStatus    `json:"health_status"`
+
+	// Duration of each phase of the refresh, e.g. "pods", plus "total"
+	Timings map[string]time.Duration `json:"timings,omitempty"`
 }
 
-// PodStatus represents the status of pods in a namespace
+// Feature is a part of the cluster status that needs specific RBAC permissions
+type Feature string
+
+// Features gated by RBAC permissions
+const (
+	FeaturePods       Feature = "Pods"
+	FeatureNamespaces Feature = "Namespace List"
+	FeatureNodes      Feature = "Nodes"
+	FeatureResources  Feature = "Resource Usage"
+	FeatureWorkloads  Feature = "Workloads"
+	FeatureJobs       Feature = "Jobs"
+	FeatureEvents     Feature = "Events"
+)
+
+// AllFeatures lists the RBAC-gated features in display order
+var AllFeatures = []Feature{
+	FeaturePods, FeatureNamespaces, FeatureNodes, FeatureResources,
+	FeatureWorkloads, FeatureJobs, FeatureEvents,
+}
+
+// Permissions records which features the current user may access
+type Permissions struct {
+	Denied     map[Feature]string `json:"denied,omitempty"`     // Reason per disabled feature, e.g. "cannot list nodes"
+	Namespaces []string           `json:"namespaces,omitempty"` // Queried instead of all namespaces when pods cannot be listed cluster-wide
+}
+
+// Allowed reports whether the feature may be used; a nil Permissions allows everything
+func (p *Permissions) Allowed(feature Feature) bool {
+	if p == nil {
+		return true
+	}
+	_, denied := p.Denied[feature]
+	return !denied
+}
+
+// Degraded reports whether any feature is disabled or namespaces are restricted
+func (p *Permissions) Degraded() bool {
+	return p != nil && (len(p.Denied) > 0 || len(p.Namespaces) > 0)
+}
+
+// PodStatus represents the status of pods in a namespace.
+// Pending and running pods with a detected container issue are counted under
+// the issue counters instead of Pending/RunningNotReady.
 type PodStatus struct {
 	Total           int         `json:"total"`
 	Running         int         `json:"running"`
@@ -61,17 +217,314 @@ type PodStatus struct {
 	Failed          int         `json:"failed"`
 	Unknown         int         `json:"unknown"`
 	Completed       int         `json:"completed"`
-	Details         []PodDetail `json:"details"`
+	CrashLoop       int         `json:"crash_loop"`
+	ImagePullError  int         `json:"image_pull_error"`
+	ConfigError     int         `json:"config_error"`
+	OOMKilled       int         `json:"oom_killed"`
+	FailedJobPods   int         `json:"failed_job_pods"` // Failed pods owned by Jobs; judged via JobStatus instead
+	Details         []PodDetail `json:"details"`         // The most relevant pods of each category
+
+	// Pods left out of Details per category on large clusters
+	Omitted map[PodCategory]int `json:"omitted,omitempty"`
 }
 
 // PodDetail represents detailed information about a pod
 type PodDetail struct {
+	Name      string           `json:"name"`
+	Namespace string           `json:"namespace"`
+	Phase     string           `json:"phase"`
+	Ready     bool             `json:"ready"`
+	Restarts  int32            `json:"restarts"`
+	Age       time.Duration    `json:"age"`
+	Reason    string           `json:"reason,omitempty"` // Most significant container or pod reason
+	Issue     PodIssue         `json:"issue"`
+	Issues    []ContainerIssue `json:"issues,omitempty"`
+	OwnerKind string           `json:"owner_kind,omitempty"`
+	OwnerName string           `json:"owner_name,omitempty"`
+}
+
+// PodCategory is the pod submenu a pod is listed in
+type PodCategory string
+
+// Pod categories; container issues take precedence over the pod phase
+const (
+	PodCategoryReady       PodCategory = "ready"
+	PodCategoryNotReady    PodCategory = "not_ready"
+	PodCategoryPending     PodCategory = "pending"
+	PodCategoryCompleted   PodCategory = "completed"
+	PodCategoryFailed      PodCategory = "failed"
+	PodCategoryUnknown     PodCategory = "unknown"
+	PodCategoryCrashLoop   PodCategory = "crash_loop"
+	PodCategoryImagePull   PodCategory = "image_pull"
+	PodCategoryConfigError PodCategory = "config_error"
+	PodCategoryOOMKilled   PodCategory = "oom_killed"
+)
+
+// Category returns the category the pod is listed under
+func (d PodDetail) Category() PodCategory {
+	switch d.Issue {
+	case PodIssueCrashLoop:
+		return PodCategoryCrashLoop
+	case PodIssueImagePull:
+		return PodCategoryImagePull
+	case PodIssueConfigError:
+		return PodCategoryConfigError
+	case PodIssueOOMKilled:
+		return PodCategoryOOMKilled
+	}
+
+	switch d.Phase {
+	case "Running":
+		if d.Ready {
+			return PodCategoryReady
+		}
+		return PodCategoryNotReady
+	case "Pending":
+		return PodCategoryPending
+	case "Succeeded":
+		return PodCategoryCompleted
+	case "Failed":
+		return PodCategoryFailed
+	default:
+		return PodCategoryUnknown
+	}
+}
+
+// Container state values for ContainerIssue
+const (
+	ContainerStateWaiting        = "waiting"
+	ContainerStateTerminated     = "terminated"
+	ContainerStateLastTerminated = "last_terminated"
+)
+
+// ContainerIssue describes a container that is waiting or terminated abnormally
+type ContainerIssue struct {
+	Container string `json:"container"`
+	Init      bool   `json:"init,omitempty"`
+	State     string `json:"state"`
+	Reason    string `json:"reason"`
+	Message   string `json:"message,omitempty"`
+	ExitCode  int32  `json:"exit_code,omitempty"`
+}
+
+// ContainerLog holds the tail of one container's log
+type ContainerLog struct {
+	Container string `json:"container"`
+	Init      bool   `json:"init,omitempty"`
+	Previous  bool   `json:"previous,omitempty"` // Logs from the previous, crashed instance
+	Content   string `json:"content"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Container state reasons reported by the kubelet
+const (
+	ReasonCrashLoopBackOff           = "CrashLoopBackOff"
+	ReasonImagePullBackOff           = "ImagePullBackOff"
+	ReasonErrImagePull               = "ErrImagePull"
+	ReasonInvalidImageName           = "InvalidImageName"
+	ReasonCreateContainerConfigError = "CreateContainerConfigError"
+	ReasonCreateContainerError       = "CreateContainerError"
+	ReasonRunContainerError          = "RunContainerError"
+	ReasonOOMKilled                  = "OOMKilled"
+)
+
+// PodIssue classifies a pod problem detected from its container states
+type PodIssue int
+
+const (
+	PodIssueNone PodIssue = iota
+	PodIssueCrashLoop
+	PodIssueImagePull
+	PodIssueConfigError
+	PodIssueOOMKilled
+)
+
+// String returns the string representation of the pod issue
+func (i PodIssue) String() string {
+	switch i {
+	case PodIssueCrashLoop:
+		return "CrashLoopBackOff"
+	case PodIssueImagePull:
+		return "Image Pull Error"
+	case PodIssueConfigError:
+		return "Config Error"
+	case PodIssueOOMKilled:
+		return "OOMKilled"
+	default:
+		return "None"
+	}
+}
+
+// Severity returns the health status a pod with this issue contributes
+func (i PodIssue) Severity() HealthStatus {
+	switch i {
+	case PodIssueCrashLoop, PodIssueOOMKilled:
+		return HealthCritical
+	case PodIssueImagePull, PodIssueConfigError:
+		// Frequently transient (registry throttling, secrets created after the deployment)
+		return HealthWarning
+	default:
+		return HealthHealthy
+	}
+}
+
+// PodIssueForReason maps a container state reason to a pod issue
+func PodIssueForReason(reason string) PodIssue {
+	switch reason {
+	case ReasonCrashLoopBackOff:
+		return PodIssueCrashLoop
+	case ReasonImagePullBackOff, ReasonErrImagePull, ReasonInvalidImageName:
+		return PodIssueImagePull
+	case ReasonCreateContainerConfigError, ReasonCreateContainerError, ReasonRunContainerError:
+		return PodIssueConfigError
+	case ReasonOOMKilled:
+		return PodIssueOOMKilled
+	default:
+		return PodIssueNone
+	}
+}
+
+// NodeStatus represents the health of the cluster's nodes
+type NodeStatus struct {
+	Total              int          `json:"total"`
+	Ready              int          `json:"ready"`
+	NotReady           int          `json:"not_ready"`
+	Cordoned           int          `json:"cordoned"`
+	MemoryPressure     int          `json:"memory_pressure"`
+	DiskPressure       int          `json:"disk_pressure"`
+	PIDPressure        int          `json:"pid_pressure"`
+	UnderPressure      int          `json:"under_pressure"` // Nodes with any memory, disk or PID pressure
+	NetworkUnavailable int          `json:"network_unavailable"`
+	Details            []NodeDetail `json:"details"`
+}
+
+// NodeDetail represents detailed information about a node
+type NodeDetail struct {
+	Name           string        `json:"name"`
+	Ready          bool          `json:"ready"`
+	Unschedulable  bool          `json:"unschedulable"`
+	Conditions     []string      `json:"conditions,omitempty"` // Active problem conditions, e.g. MemoryPressure
+	KubeletVersion string        `json:"kubelet_version"`
+	Roles          []string      `json:"roles,omitempty"`
+	Age            time.Duration `json:"age"`
+}
+
+// Workload kinds evaluated for rollout status
+const (
+	KindDeployment  = "Deployment"
+	KindStatefulSet = "StatefulSet"
+	KindDaemonSet   = "DaemonSet"
+)
+
+// WorkloadState represents the rollout state of a workload
+type WorkloadState int
+
+const (
+	WorkloadHealthy WorkloadState = iota
+	WorkloadRollingOut
+	WorkloadDegraded
+	WorkloadFailed
+)
+
+// String returns the string representation of the workload state
+func (s WorkloadState) String() string {
+	switch s {
+	case WorkloadHealthy:
+		return "Healthy"
+	case WorkloadRollingOut:
+		return "Rolling Out"
+	case WorkloadDegraded:
+		return "Degraded"
+	case WorkloadFailed:
+		return "Failed"
+	default:
+		return "Unknown"
+	}
+}
+
+// WorkloadStatus represents the rollout status of workloads in a namespace
+type WorkloadStatus struct {
+	Total      int              `json:"total"`
+	Healthy    int              `json:"healthy"`
+	RollingOut int              `json:"rolling_out"`
+	Degraded   int              `json:"degraded"`
+	Failed     int              `json:"failed"`
+	Details    []WorkloadDetail `json:"details"`
+}
+
+// WorkloadDetail represents the rollout status of a single workload
+type WorkloadDetail struct {
+	Kind      string        `json:"kind"`
 	Name      string        `json:"name"`
 	Namespace string        `json:"namespace"`
-	Phase     string        `json:"phase"`
-	Ready     bool          `json:"ready"`
-	Restarts  int32         `json:"restarts"`
-	Age       time.Duration `json:"age"`
+	Desired   int32         `json:"desired"`
+	Updated   int32         `json:"updated"`
+	Ready     int32         `json:"ready"`
+	Available int32         `json:"available"`
+	State     WorkloadState `json:"state"`
+	Message   string        `json:"message,omitempty"`
+}
+
+// JobState represents the outcome of a Job
+type JobState int
+
+const (
+	JobRunning JobState = iota
+	JobSucceeded
+	JobFailed
+)
+
+// String returns the string representation of the job state
+func (s JobState) String() string {
+	switch s {
+	case JobRunning:
+		return "Running"
+	case JobSucceeded:
+		return "Succeeded"
+	case JobFailed:
+		return "Failed"
+	default:
+		return "Unknown"
+	}
+}
+
+// JobStatus represents Jobs and CronJobs in a namespace
+type JobStatus struct {
+	CronJobs        []CronJobDetail `json:"cron_jobs"`
+	Jobs            []JobDetail     `json:"jobs"` // Jobs not owned by a CronJob
+	Active          int             `json:"active"`
+	Failed          int             `json:"failed"`           // Standalone Jobs that failed recently
+	FailedCronJobs  int             `json:"failed_cron_jobs"` // CronJobs whose latest run failed
+	Suspended       int             `json:"suspended"`
+	MissedSchedules int             `json:"missed_schedules"`
+}
+
+// JobDetail represents a single Job run
+type JobDetail struct {
+	Name           string    `json:"name"`
+	Namespace      string    `json:"namespace"`
+	CronJob        string    `json:"cron_job,omitempty"`
+	Active         int32     `json:"active"`
+	Succeeded      int32     `json:"succeeded"`
+	Failed         int32     `json:"failed"`
+	BackoffLimit   int32     `json:"backoff_limit"`
+	State          JobState  `json:"state"`
+	Reason         string    `json:"reason,omitempty"` // e.g. BackoffLimitExceeded
+	StartTime      time.Time `json:"start_time"`
+	CompletionTime time.Time `json:"completion_time,omitempty"`
+}
+
+// CronJobDetail represents a CronJob and its most recent run
+type CronJobDetail struct {
+	Name               string     `json:"name"`
+	Namespace          string     `json:"namespace"`
+	Schedule           string     `json:"schedule"`
+	Suspended          bool       `json:"suspended"`
+	Active             int        `json:"active"`
+	LastScheduleTime   time.Time  `json:"last_schedule_time,omitempty"`
+	LastSuccessfulTime time.Time  `json:"last_successful_time,omitempty"`
+	LastRun            *JobDetail `json:"last_run,omitempty"`
+	MissedSchedule     bool       `json:"missed_schedule"`
 }
 
 // Event represents a Kubernetes event
@@ -80,6 +533,9 @@ type Event struct {
 	Reason    string    `json:"reason"`
 	Message   string    `json:"message"`
 	Object    string    `json:"object"`
+	Kind      string    `json:"kind,omitempty"`
+	Namespace string    `json:"namespace,omitempty"`
+	Count     int32     `json:"count,omitempty"`
 	Timestamp time.Time `json:"timestamp"`
 }
 
@@ -90,6 +546,13 @@ type TrayState struct {
 	Error      string       `json:"error,omitempty"`
 }
 
+// KubeContext describes a kubeconfig context and the file it was loaded from
+type KubeContext struct {
+	Name     string   `json:"name"`
+	Source   string   `json:"source"`             // File whose definition is used
+	Shadowed []string `json:"shadowed,omitempty"` // Later files defining the same name, which are ignored
+}
+
 // MenuAction represents an action that can be performed from the menu
 type MenuAction int
 
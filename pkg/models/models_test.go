@@ -24,6 +24,18 @@ func TestHealthStatus_String(t *testing.T) {
 	}
 }
 
+func TestConnectionState_Hint(t *testing.T) {
+	if hint := ConnectionOK.Hint(); hint != "" {
+		t.Errorf("Expected no hint when connected, got '%s'", hint)
+	}
+
+	for state := ConnectionUnreachable; state <= ConnectionFailed; state++ {
+		if state.Hint() == "" {
+			t.Errorf("Expected a hint for %s", state)
+		}
+	}
+}
+
 func TestMenuAction_String(t *testing.T) {
 	tests := []struct {
 		action   MenuAction
@@ -209,3 +221,95 @@ func TestClusterStatusWithResources(t *testing.T) {
 		t.Errorf("Expected CPU percentage 37.5, got %f", status.Resources.CPU.Percentage)
 	}
 }
+
+func TestPodIssueForReason(t *testing.T) {
+	tests := []struct {
+		reason   string
+		expected PodIssue
+	}{
+		{ReasonCrashLoopBackOff, PodIssueCrashLoop},
+		{ReasonImagePullBackOff, PodIssueImagePull},
+		{ReasonErrImagePull, PodIssueImagePull},
+		{ReasonInvalidImageName, PodIssueImagePull},
+		{ReasonCreateContainerConfigError, PodIssueConfigError},
+		{ReasonCreateContainerError, PodIssueConfigError},
+		{ReasonRunContainerError, PodIssueConfigError},
+		{ReasonOOMKilled, PodIssueOOMKilled},
+		{"ContainerCreating", PodIssueNone},
+		{"", PodIssueNone},
+	}
+
+	for _, test := range tests {
+		result := PodIssueForReason(test.reason)
+		if result != test.expected {
+			t.Errorf("Reason %q: expected %s, got %s", test.reason, test.expected, result)
+		}
+	}
+}
+
+func TestPodDetail_Category(t *testing.T) {
+	tests := []struct {
+		detail   PodDetail
+		expected PodCategory
+	}{
+		{PodDetail{Phase: "Running", Ready: true}, PodCategoryReady},
+		{PodDetail{Phase: "Running"}, PodCategoryNotReady},
+		{PodDetail{Phase: "Pending"}, PodCategoryPending},
+		{PodDetail{Phase: "Succeeded"}, PodCategoryCompleted},
+		{PodDetail{Phase: "Failed"}, PodCategoryFailed},
+		{PodDetail{Phase: "Unknown"}, PodCategoryUnknown},
+		{PodDetail{Phase: "Running", Issue: PodIssueCrashLoop}, PodCategoryCrashLoop},
+		{PodDetail{Phase: "Pending", Issue: PodIssueImagePull}, PodCategoryImagePull},
+	}
+
+	for _, test := range tests {
+		result := test.detail.Category()
+		if result != test.expected {
+			t.Errorf("Phase %q, issue %s: expected %s, got %s", test.detail.Phase, test.detail.Issue, test.expected, result)
+		}
+	}
+}
+
+func TestPodIssue_Severity(t *testing.T) {
+	tests := []struct {
+		issue    PodIssue
+		expected HealthStatus
+	}{
+		{PodIssueCrashLoop, HealthCritical},
+		{PodIssueOOMKilled, HealthCritical},
+		{PodIssueImagePull, HealthWarning},
+		{PodIssueConfigError, HealthWarning},
+		{PodIssueNone, HealthHealthy},
+	}
+
+	for _, test := range tests {
+		result := test.issue.Severity()
+		if result != test.expected {
+			t.Errorf("Issue %s: expected severity %s, got %s", test.issue, test.expected, result)
+		}
+	}
+}
+
+func TestWorstHealth(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []HealthStatus
+		expected HealthStatus
+	}{
+		{"No statuses", nil, HealthUnknown},
+		{"All healthy", []HealthStatus{HealthHealthy, HealthHealthy}, HealthHealthy},
+		{"Warning wins over healthy", []HealthStatus{HealthHealthy, HealthWarning}, HealthWarning},
+		{"Critical wins over warning", []HealthStatus{HealthWarning, HealthCritical, HealthHealthy}, HealthCritical},
+		{"Unknown wins over healthy", []HealthStatus{HealthHealthy, HealthUnknown}, HealthUnknown},
+		{"Warning wins over unknown", []HealthStatus{HealthUnknown, HealthWarning}, HealthWarning},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := WorstHealth(test.statuses...)
+			if result != test.expected {
+				t.Errorf("Expected %s, got %s", test.expected, result)
+			}
+		})
+	}
+}